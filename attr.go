@@ -0,0 +1,304 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import "unicode"
+
+// Permission is the access permission of a VG or LV.
+type Permission string
+
+const (
+	PermissionWriteable        Permission = "writeable"
+	PermissionReadOnly         Permission = "read-only"
+	PermissionReadOnlyOverride Permission = "read-only-override" // Read-only activation of a writeable LV.
+)
+
+// AllocationPolicy is the policy used when allocating physical extents.
+type AllocationPolicy string
+
+const (
+	AllocationAnywhere   AllocationPolicy = "anywhere"
+	AllocationContiguous AllocationPolicy = "contiguous"
+	AllocationInherit    AllocationPolicy = "inherit"
+	AllocationCling      AllocationPolicy = "cling"
+	AllocationNormal     AllocationPolicy = "normal"
+)
+
+// LVVolumeType is the kind of LV, as reported by the first lv_attr character.
+type LVVolumeType string
+
+const (
+	LVVolumeTypeNone            LVVolumeType = ""
+	LVVolumeTypeCache           LVVolumeType = "cache"
+	LVVolumeTypeMirrored        LVVolumeType = "mirrored"
+	LVVolumeTypeMirroredNoSync  LVVolumeType = "mirrored-nosync"
+	LVVolumeTypeOrigin          LVVolumeType = "origin"
+	LVVolumeTypeMergingOrigin   LVVolumeType = "merging-origin"
+	LVVolumeTypeRAID            LVVolumeType = "raid"
+	LVVolumeTypeRAIDNoSync      LVVolumeType = "raid-nosync"
+	LVVolumeTypeSnapshot        LVVolumeType = "snapshot"
+	LVVolumeTypeMergingSnapshot LVVolumeType = "merging-snapshot"
+	LVVolumeTypePVMove          LVVolumeType = "pvmove"
+	LVVolumeTypeVirtual         LVVolumeType = "virtual"
+	LVVolumeTypeImage           LVVolumeType = "image"
+	LVVolumeTypeImageOutOfSync  LVVolumeType = "image-out-of-sync"
+	LVVolumeTypeMirrorLog       LVVolumeType = "mirror-log"
+	LVVolumeTypeConverting      LVVolumeType = "converting"
+	LVVolumeTypeThin            LVVolumeType = "thin"
+	LVVolumeTypeThinPool        LVVolumeType = "thin-pool"
+	LVVolumeTypeThinPoolData    LVVolumeType = "thin-pool-data"
+	LVVolumeTypeVDOPool         LVVolumeType = "vdo-pool"
+	LVVolumeTypeVDOPoolData     LVVolumeType = "vdo-pool-data"
+	LVVolumeTypeMetadata        LVVolumeType = "metadata"
+	LVVolumeTypeUnknown         LVVolumeType = "unknown"
+)
+
+// LVState is the activation state of an LV.
+type LVState string
+
+const (
+	LVStateInactive                     LVState = ""
+	LVStateActive                       LVState = "active"
+	LVStateHistorical                   LVState = "historical"
+	LVStateSuspended                    LVState = "suspended"
+	LVStateInvalidSnapshot              LVState = "invalid-snapshot"
+	LVStateSuspendedInvalidSnapshot     LVState = "suspended-invalid-snapshot"
+	LVStateSnapshotMergeFailed          LVState = "snapshot-merge-failed"
+	LVStateSuspendedSnapshotMergeFailed LVState = "suspended-snapshot-merge-failed"
+	LVStateNoTables                     LVState = "no-tables"      // Mapped device present without tables.
+	LVStateInactiveTable                LVState = "inactive-table" // Mapped device present with an inactive table.
+	LVStateCheckNeeded                  LVState = "check-needed"
+	LVStateSuspendedCheckNeeded         LVState = "suspended-check-needed"
+	LVStateUnknown                      LVState = "unknown"
+)
+
+// LVTargetType is the kind of device-mapper target used by an LV.
+type LVTargetType string
+
+const (
+	LVTargetTypeNone     LVTargetType = ""
+	LVTargetTypeCache    LVTargetType = "cache"
+	LVTargetTypeMirror   LVTargetType = "mirror"
+	LVTargetTypeRAID     LVTargetType = "raid"
+	LVTargetTypeSnapshot LVTargetType = "snapshot"
+	LVTargetTypeThin     LVTargetType = "thin"
+	LVTargetTypeVirtual  LVTargetType = "virtual"
+	LVTargetTypeUnknown  LVTargetType = "unknown"
+)
+
+// LVHealth is the health of an LV. Degraded RAID LVs report partial if an
+// image is missing, refresh needed if an image failed and needs to be
+// refreshed or replaced, or mismatches exist after a scrub check.
+type LVHealth string
+
+const (
+	LVHealthOK               LVHealth = ""
+	LVHealthPartial          LVHealth = "partial"
+	LVHealthRefreshNeeded    LVHealth = "refresh needed"   // RAID only.
+	LVHealthMismatchesExist  LVHealth = "mismatches exist" // RAID only.
+	LVHealthWriteMostly      LVHealth = "writemostly"      // RAID1 only.
+	LVHealthFailed           LVHealth = "failed"           // Thin pools and volumes only.
+	LVHealthOutOfData        LVHealth = "out of data"      // Thin pools only.
+	LVHealthMetadataReadOnly LVHealth = "metadata read only"
+	LVHealthError            LVHealth = "error" // Writecache only.
+	LVHealthUnknown          LVHealth = "unknown"
+)
+
+// LVAttr is the decoded form of the lv_attr field.
+type LVAttr struct {
+	VolumeType       LVVolumeType     // Type of the volume.
+	Permission       Permission       // Access permission.
+	AllocationPolicy AllocationPolicy // Allocation policy.
+	AllocationLocked bool             // Set if the LV is locked against allocation changes.
+	FixedMinor       bool             // Set if the LV has a fixed minor number.
+	State            LVState          // Activation state.
+	Open             bool             // Set if the LV device is open.
+	TargetType       LVTargetType     // Device-mapper target type.
+	Zero             bool             // Set if newly allocated data blocks are zeroed before use.
+	Health           LVHealth         // Volume health.
+	SkipActivation   bool             // Set if the LV is skipped on activation.
+}
+
+// PVAttr is the decoded form of the pv_attr field.
+type PVAttr struct {
+	Duplicate   bool // Set if the PV is an unchosen duplicate.
+	Allocatable bool // Set if the PV can be used for allocation.
+	Used        bool // Set if the PV is used but not in a VG.
+	Exported    bool // Set if the PV is exported.
+	Missing     bool // Set if the PV is missing.
+}
+
+// VGAttr is the decoded form of the vg_attr field.
+type VGAttr struct {
+	Permission       Permission       // Access permission.
+	Resizeable       bool             // Set if PVs can be added to or removed from the VG.
+	Exported         bool             // Set if the VG is exported.
+	Partial          bool             // Set if one or more PVs are missing.
+	AllocationPolicy AllocationPolicy // Allocation policy.
+	Clustered        bool             // Set if the VG is clustered.
+	Shared           bool             // Set if the VG is shared.
+}
+
+// Attr decodes the LV attributes.
+func (lv LogicalVolume) Attr() LVAttr {
+	attr := padAttr(lv.Attributes, 10)
+
+	return LVAttr{
+		VolumeType: lookupAttr(attr[0], map[byte]LVVolumeType{
+			'-': LVVolumeTypeNone,
+			'C': LVVolumeTypeCache,
+			'm': LVVolumeTypeMirrored,
+			'M': LVVolumeTypeMirroredNoSync,
+			'o': LVVolumeTypeOrigin,
+			'O': LVVolumeTypeMergingOrigin,
+			'r': LVVolumeTypeRAID,
+			'R': LVVolumeTypeRAIDNoSync,
+			's': LVVolumeTypeSnapshot,
+			'S': LVVolumeTypeMergingSnapshot,
+			'p': LVVolumeTypePVMove,
+			'v': LVVolumeTypeVirtual,
+			'i': LVVolumeTypeImage,
+			'I': LVVolumeTypeImageOutOfSync,
+			'l': LVVolumeTypeMirrorLog,
+			'c': LVVolumeTypeConverting,
+			'V': LVVolumeTypeThin,
+			't': LVVolumeTypeThinPool,
+			'T': LVVolumeTypeThinPoolData,
+			'd': LVVolumeTypeVDOPool,
+			'D': LVVolumeTypeVDOPoolData,
+			'e': LVVolumeTypeMetadata,
+		}, LVVolumeTypeUnknown),
+		Permission:       parsePermission(attr[1]),
+		AllocationPolicy: parseAllocationPolicy(attr[2]),
+		AllocationLocked: unicode.IsUpper(rune(attr[2])),
+		FixedMinor:       attr[3] == 'm',
+		State: lookupAttr(attr[4], map[byte]LVState{
+			'-': LVStateInactive,
+			'a': LVStateActive,
+			'h': LVStateHistorical,
+			's': LVStateSuspended,
+			'I': LVStateInvalidSnapshot,
+			'S': LVStateSuspendedInvalidSnapshot,
+			'm': LVStateSnapshotMergeFailed,
+			'M': LVStateSuspendedSnapshotMergeFailed,
+			'd': LVStateNoTables,
+			'i': LVStateInactiveTable,
+			'c': LVStateCheckNeeded,
+			'C': LVStateSuspendedCheckNeeded,
+		}, LVStateUnknown),
+		Open: attr[5] == 'o',
+		TargetType: lookupAttr(attr[6], map[byte]LVTargetType{
+			'-': LVTargetTypeNone,
+			'C': LVTargetTypeCache,
+			'm': LVTargetTypeMirror,
+			'r': LVTargetTypeRAID,
+			's': LVTargetTypeSnapshot,
+			't': LVTargetTypeThin,
+			'v': LVTargetTypeVirtual,
+		}, LVTargetTypeUnknown),
+		Zero: attr[7] == 'z',
+		Health: lookupAttr(attr[8], map[byte]LVHealth{
+			'-': LVHealthOK,
+			'p': LVHealthPartial,
+			'r': LVHealthRefreshNeeded,
+			'm': LVHealthMismatchesExist,
+			'w': LVHealthWriteMostly,
+			'F': LVHealthFailed,
+			'D': LVHealthOutOfData,
+			'M': LVHealthMetadataReadOnly,
+			'E': LVHealthError,
+		}, LVHealthUnknown),
+		SkipActivation: attr[9] == 'k',
+	}
+}
+
+// Attr decodes the PV attributes.
+func (pv PhysicalVolume) Attr() PVAttr {
+	attr := padAttr(pv.Attributes, 3)
+
+	return PVAttr{
+		Duplicate:   attr[0] == 'd',
+		Allocatable: attr[0] == 'a',
+		Used:        attr[0] == 'u',
+		Exported:    attr[1] == 'x',
+		Missing:     attr[2] == 'm',
+	}
+}
+
+// Attr decodes the VG attributes.
+func (vg VolumeGroup) Attr() VGAttr {
+	attr := padAttr(vg.Attributes, 6)
+
+	return VGAttr{
+		Permission:       parsePermission(attr[0]),
+		Resizeable:       attr[1] == 'z',
+		Exported:         attr[2] == 'x',
+		Partial:          attr[3] == 'p',
+		AllocationPolicy: parseAllocationPolicy(attr[4]),
+		Clustered:        attr[5] == 'c',
+		Shared:           attr[5] == 's',
+	}
+}
+
+func parsePermission(c byte) Permission {
+	switch c {
+	case 'w':
+		return PermissionWriteable
+	case 'r':
+		return PermissionReadOnly
+	case 'R':
+		return PermissionReadOnlyOverride
+	default:
+		return ""
+	}
+}
+
+func parseAllocationPolicy(c byte) AllocationPolicy {
+	switch unicode.ToLower(rune(c)) {
+	case 'a':
+		return AllocationAnywhere
+	case 'c':
+		return AllocationContiguous
+	case 'i':
+		return AllocationInherit
+	case 'l':
+		return AllocationCling
+	case 'n':
+		return AllocationNormal
+	default:
+		return ""
+	}
+}
+
+// padAttr pads an attribute string to the expected length, so that older
+// versions of lvm that report fewer attributes can still be decoded.
+func padAttr(attr string, n int) string {
+	for len(attr) < n {
+		attr += "-"
+	}
+
+	return attr
+}
+
+func lookupAttr[T any](c byte, values map[byte]T, unknown T) T {
+	if v, ok := values[c]; ok {
+		return v
+	}
+
+	return unknown
+}
@@ -0,0 +1,148 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogicalVolumeAttr(t *testing.T) {
+	attr := lvm2.LogicalVolume{Attributes: "-wi-a-----"}.Attr()
+	require.Equal(t, lvm2.LVAttr{
+		VolumeType:       lvm2.LVVolumeTypeNone,
+		Permission:       lvm2.PermissionWriteable,
+		AllocationPolicy: lvm2.AllocationInherit,
+		State:            lvm2.LVStateActive,
+	}, attr)
+
+	attr = lvm2.LogicalVolume{Attributes: "rwi-aor-r-"}.Attr()
+	require.Equal(t, lvm2.LVVolumeTypeRAID, attr.VolumeType)
+	require.True(t, attr.Open)
+	require.Equal(t, lvm2.LVTargetTypeRAID, attr.TargetType)
+	require.Equal(t, lvm2.LVHealthRefreshNeeded, attr.Health)
+
+	attr = lvm2.LogicalVolume{Attributes: "twC-s-tz-k"}.Attr()
+	require.Equal(t, lvm2.LVVolumeTypeThinPool, attr.VolumeType)
+	require.Equal(t, lvm2.AllocationContiguous, attr.AllocationPolicy)
+	require.True(t, attr.AllocationLocked)
+	require.Equal(t, lvm2.LVStateSuspended, attr.State)
+	require.Equal(t, lvm2.LVTargetTypeThin, attr.TargetType)
+	require.True(t, attr.Zero)
+	require.True(t, attr.SkipActivation)
+
+	attr = lvm2.LogicalVolume{Attributes: "sri-I-s---"}.Attr()
+	require.Equal(t, lvm2.LVVolumeTypeSnapshot, attr.VolumeType)
+	require.Equal(t, lvm2.PermissionReadOnly, attr.Permission)
+	require.Equal(t, lvm2.LVStateInvalidSnapshot, attr.State)
+}
+
+func TestPhysicalVolumeAttr(t *testing.T) {
+	require.Equal(t, lvm2.PVAttr{Allocatable: true}, lvm2.PhysicalVolume{Attributes: "a--"}.Attr())
+	require.Equal(t, lvm2.PVAttr{Exported: true, Missing: true}, lvm2.PhysicalVolume{Attributes: "-xm"}.Attr())
+}
+
+func TestVolumeGroupAttr(t *testing.T) {
+	require.Equal(t, lvm2.VGAttr{
+		Permission:       lvm2.PermissionWriteable,
+		Resizeable:       true,
+		AllocationPolicy: lvm2.AllocationNormal,
+	}, lvm2.VolumeGroup{Attributes: "wz--n-"}.Attr())
+
+	attr := lvm2.VolumeGroup{Attributes: "rzxpcs"}.Attr()
+	require.Equal(t, lvm2.PermissionReadOnly, attr.Permission)
+	require.True(t, attr.Exported)
+	require.True(t, attr.Partial)
+	require.Equal(t, lvm2.AllocationContiguous, attr.AllocationPolicy)
+	require.True(t, attr.Shared)
+}
+
+func TestLogicalVolumeHealth(t *testing.T) {
+	require.Equal(t, lvm2.LVHealthOK, lvm2.LogicalVolume{Attributes: "rwi-a-r---"}.Health())
+	require.Equal(t, lvm2.LVHealthMismatchesExist, lvm2.LogicalVolume{
+		Attributes:   "rwi-a-r---",
+		HealthStatus: lvm2.LVHealthMismatchesExist,
+	}.Health())
+	require.Equal(t, lvm2.LVHealthPartial, lvm2.LogicalVolume{Attributes: "rwi-a-r-p-"}.Health())
+}
+
+func TestLogicalVolumePredicates(t *testing.T) {
+	linear := lvm2.LogicalVolume{Attributes: "-wi-ao----", Type: lvm2.LVTypeLinear, Active: "active", DeviceOpen: true}
+	require.True(t, linear.IsActive())
+	require.True(t, linear.IsOpen())
+	require.False(t, linear.IsSnapshot())
+	require.False(t, linear.IsThinPool())
+
+	inactive := lvm2.LogicalVolume{Attributes: "-wi-------", Type: lvm2.LVTypeLinear}
+	require.False(t, inactive.IsActive())
+	require.False(t, inactive.IsOpen())
+
+	snapshot := lvm2.LogicalVolume{Attributes: "swi-a-s---", Type: lvm2.LVTypeSnapshot, Active: "active"}
+	require.True(t, snapshot.IsActive())
+	require.False(t, snapshot.IsOpen())
+	require.True(t, snapshot.IsSnapshot())
+	require.False(t, snapshot.IsThinPool())
+
+	merging := lvm2.LogicalVolume{Attributes: "Swi-a-s---"}
+	require.True(t, merging.IsSnapshot())
+
+	pool := lvm2.LogicalVolume{Attributes: "twi-aotz--", Type: lvm2.LVTypeThinPool, Active: "active"}
+	require.True(t, pool.IsActive())
+	require.True(t, pool.IsOpen())
+	require.False(t, pool.IsSnapshot())
+	require.True(t, pool.IsThinPool())
+
+	t.Log("Thin volumes are not thick snapshots")
+
+	thin := lvm2.LogicalVolume{Attributes: "Vwi-a-tz--", Type: lvm2.LVTypeThin, Origin: "base"}
+	require.True(t, thin.IsActive())
+	require.False(t, thin.IsSnapshot())
+	require.False(t, thin.IsThinPool())
+}
+
+func TestLogicalVolumeSnapPercent(t *testing.T) {
+	snapshot := lvm2.LogicalVolume{Attributes: "swi-a-s---", Type: lvm2.LVTypeSnapshot, DataPercent: "3.52", SnapshotPercent: "3.52"}
+	percent, ok := snapshot.SnapPercent()
+	require.True(t, ok)
+	require.InDelta(t, 3.52, percent, 0.001)
+	require.False(t, snapshot.IsSnapshotFull())
+
+	full := lvm2.LogicalVolume{Attributes: "swi-a-s---", Type: lvm2.LVTypeSnapshot, DataPercent: "100.00"}
+	require.True(t, full.IsSnapshotFull())
+
+	invalid := lvm2.LogicalVolume{Attributes: "swi-I-s---", Type: lvm2.LVTypeSnapshot, DataPercent: "100.00", SnapshotInvalid: true}
+	require.True(t, invalid.IsSnapshotFull())
+
+	t.Log("Older versions only report snap_percent")
+
+	percent, ok = lvm2.LogicalVolume{Attributes: "swi-a-s---", SnapshotPercent: "50.00"}.SnapPercent()
+	require.True(t, ok)
+	require.InDelta(t, 50.0, percent, 0.001)
+
+	t.Log("Inactive snapshots and other volumes have no usage")
+
+	_, ok = lvm2.LogicalVolume{Attributes: "swi---s---", Type: lvm2.LVTypeSnapshot}.SnapPercent()
+	require.False(t, ok)
+
+	pool := lvm2.LogicalVolume{Attributes: "twi-aotz--", Type: lvm2.LVTypeThinPool, DataPercent: "100.00"}
+	_, ok = pool.SnapPercent()
+	require.False(t, ok)
+	require.False(t, pool.IsSnapshotFull())
+}
@@ -0,0 +1,84 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Batch runs a sequence of steps in order. If a step fails, the rollback
+// actions of the steps that have already completed are run in reverse order.
+// This isn't a true transaction, but gives best-effort cleanup when a
+// multi-step operation (eg. creating a PV, VG and LV) fails part way through.
+type Batch struct {
+	steps []batchStep
+}
+
+type batchStep struct {
+	run      func(ctx context.Context) error
+	rollback func(ctx context.Context) error
+}
+
+// Add a step that has no compensating action.
+func (b *Batch) Add(run func(ctx context.Context) error) {
+	b.AddWithRollback(run, nil)
+}
+
+// Add a step along with an action that undoes it, eg. removing the volume
+// group that the step created.
+func (b *Batch) AddWithRollback(run, rollback func(ctx context.Context) error) {
+	b.steps = append(b.steps, batchStep{run: run, rollback: rollback})
+}
+
+// Run the steps in order, stopping at the first failure. On failure the
+// completed steps are rolled back and the step error is returned, along with
+// any errors encountered during rollback.
+func (b *Batch) Run(ctx context.Context) error {
+	for i, step := range b.steps {
+		if err := step.run(ctx); err != nil {
+			err = fmt.Errorf("step %d failed: %w", i+1, err)
+
+			// Roll back even if the context has been cancelled.
+			if rollbackErr := b.rollback(context.Background(), i); rollbackErr != nil {
+				return errors.Join(err, rollbackErr)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollback runs the rollback actions of the first n steps in reverse order.
+func (b *Batch) rollback(ctx context.Context, n int) error {
+	var errs []error
+	for i := n - 1; i >= 0; i-- {
+		if b.steps[i].rollback == nil {
+			continue
+		}
+
+		if err := b.steps[i].rollback(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to roll back step %d: %w", i+1, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
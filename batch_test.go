@@ -0,0 +1,105 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		var calls []string
+
+		var b lvm2.Batch
+		for _, name := range []string{"pv", "vg", "lv"} {
+			name := name
+			b.AddWithRollback(func(ctx context.Context) error {
+				calls = append(calls, "create "+name)
+				return nil
+			}, func(ctx context.Context) error {
+				calls = append(calls, "remove "+name)
+				return nil
+			})
+		}
+
+		require.NoError(t, b.Run(ctx))
+		require.Equal(t, []string{"create pv", "create vg", "create lv"}, calls)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		var calls []string
+		errFailed := errors.New("failed")
+
+		var b lvm2.Batch
+		b.AddWithRollback(func(ctx context.Context) error {
+			calls = append(calls, "create pv")
+			return nil
+		}, func(ctx context.Context) error {
+			calls = append(calls, "remove pv")
+			return nil
+		})
+		b.AddWithRollback(func(ctx context.Context) error {
+			calls = append(calls, "create vg")
+			return nil
+		}, func(ctx context.Context) error {
+			calls = append(calls, "remove vg")
+			return nil
+		})
+		b.AddWithRollback(func(ctx context.Context) error {
+			calls = append(calls, "create lv")
+			return errFailed
+		}, func(ctx context.Context) error {
+			calls = append(calls, "remove lv")
+			return nil
+		})
+		b.Add(func(ctx context.Context) error {
+			calls = append(calls, "unreachable")
+			return nil
+		})
+
+		err := b.Run(ctx)
+		require.ErrorIs(t, err, errFailed)
+		require.Equal(t, []string{"create pv", "create vg", "create lv", "remove vg", "remove pv"}, calls)
+	})
+
+	t.Run("Rollback failure", func(t *testing.T) {
+		errFailed := errors.New("failed")
+		errRollback := errors.New("rollback failed")
+
+		var b lvm2.Batch
+		b.AddWithRollback(func(ctx context.Context) error {
+			return nil
+		}, func(ctx context.Context) error {
+			return errRollback
+		})
+		b.Add(func(ctx context.Context) error {
+			return errFailed
+		})
+
+		err := b.Run(ctx)
+		require.ErrorIs(t, err, errFailed)
+		require.ErrorIs(t, err, errRollback)
+	})
+}
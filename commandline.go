@@ -0,0 +1,284 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dpeckett/args"
+)
+
+// Default report fields, used unless specific fields are requested.
+const (
+	defaultPVFields = "pv_all,vg_name,vg_extent_size"
+	defaultVGFields = "vg_all"
+	defaultLVFields = "lv_all,seg_all,vg_name"
+)
+
+// CommandLine returns the arguments that the client passes to the lvm
+// executable for the given options, without running the command. This is
+// useful for previewing and logging commands. Arguments added by client
+// options, such as WithDevicesFile, are not included.
+// Report options may be passed by value or by pointer, where a nil pointer
+// means the default options. CommandLine panics if opts aren't the options of
+// an operation that runs a single lvm command, eg. CloneLVOptions.
+func CommandLine(opts any) []string {
+	switch o := opts.(type) {
+	case ListPVOptions:
+		opts = &o
+	case ListVGOptions:
+		opts = &o
+	case ListLVOptions:
+		opts = &o
+	case FullReportOptions:
+		opts = &o
+	}
+
+	cl, ok := opts.(commandLiner)
+	if !ok {
+		panic(fmt.Sprintf("lvm2: no command line for %T", opts))
+	}
+
+	return cl.commandLine()
+}
+
+// commandLiner is implemented by the options of operations that run a single
+// lvm command.
+type commandLiner interface {
+	commandLine() []string
+}
+
+func (opts *ListPVOptions) commandLine() []string {
+	if opts == nil {
+		opts = &ListPVOptions{}
+	}
+
+	pvOpts := *opts
+	pvOpts.Options = nil
+	return reportCommandLine("pvs", reportFields(defaultPVFields, opts.Options), marshalArgs(pvOpts))
+}
+
+func (opts CreatePVOptions) commandLine() []string {
+	return append([]string{"pvcreate", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts UpdatePVOptions) commandLine() []string {
+	return append([]string{"pvchange", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts RemovePVOptions) commandLine() []string {
+	return append([]string{"pvremove", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts CheckPVOptions) commandLine() []string {
+	return append([]string{"pvck", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts MovePEOptions) commandLine() []string {
+	return append([]string{"pvmove", "--yes"}, marshalArgs(opts.withSourceExtents())...)
+}
+
+func (opts ResizePVOptions) commandLine() []string {
+	return append([]string{"pvresize", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ScanPVOptions) commandLine() []string {
+	return append([]string{"pvscan"}, marshalArgs(opts)...)
+}
+
+func (opts AddDeviceOptions) commandLine() []string {
+	return append([]string{"lvmdevices", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts RemoveDeviceOptions) commandLine() []string {
+	return append([]string{"lvmdevices", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts CheckDevicesOptions) commandLine() []string {
+	return append([]string{"lvmdevices", "--yes", "--check"}, marshalArgs(opts)...)
+}
+
+func (opts *ListVGOptions) commandLine() []string {
+	if opts == nil {
+		opts = &ListVGOptions{}
+	}
+
+	vgOpts := *opts
+	vgOpts.Options = nil
+	return reportCommandLine("vgs", reportFields(defaultVGFields, opts.Options), marshalArgs(vgOpts))
+}
+
+func (opts CreateVGOptions) commandLine() []string {
+	return append([]string{"vgcreate", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts UpdateVGOptions) commandLine() []string {
+	return append([]string{"vgchange", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts RemoveVGOptions) commandLine() []string {
+	return append([]string{"vgremove", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts CheckVGOptions) commandLine() []string {
+	return append([]string{"vgck", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ExportVGOptions) commandLine() []string {
+	return append([]string{"vgexport", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ImportVGOptions) commandLine() []string {
+	return append([]string{"vgimport", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ImportVGFromClonedOptions) commandLine() []string {
+	return append([]string{"vgimportclone", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts MergeVGOptions) commandLine() []string {
+	return append([]string{"vgmerge", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ExtendVGOptions) commandLine() []string {
+	return append([]string{"vgextend", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ReduceVGOptions) commandLine() []string {
+	return append([]string{"vgreduce", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts RenameVGOptions) commandLine() []string {
+	return append([]string{"vgrename", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts MovePVOptions) commandLine() []string {
+	return append([]string{"vgsplit", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts MakeVGDeviceNodesOptions) commandLine() []string {
+	return append([]string{"vgmknodes", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts *ListLVOptions) commandLine() []string {
+	if opts == nil {
+		opts = &ListLVOptions{}
+	}
+
+	lvOpts := opts.withActive()
+	lvOpts.Options = nil
+	return reportCommandLine("lvs", reportFields(defaultLVFields, opts.Options), marshalArgs(lvOpts))
+}
+
+func (opts CreateLVOptions) commandLine() []string {
+	opts.CacheSettings = cacheSettings(opts.CacheSettings)
+	return append([]string{"lvcreate", "--yes"}, marshalArgs(opts.withAllocationTags())...)
+}
+
+func (opts UpdateLVOptions) commandLine() []string {
+	opts.CacheSettings = cacheSettings(opts.CacheSettings)
+	return append([]string{"lvchange", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ScrubLVOptions) commandLine() []string {
+	return append([]string{"lvchange", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts RemoveLVOptions) commandLine() []string {
+	return append([]string{"lvremove", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ConvertLVLayoutOptions) commandLine() []string {
+	opts.CacheSettings = cacheSettings(opts.CacheSettings)
+	return append([]string{"lvconvert", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ExtendLVOptions) commandLine() []string {
+	return append([]string{"lvextend", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts ReduceLVOptions) commandLine() []string {
+	return append([]string{"lvreduce", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts RenameLVOptions) commandLine() []string {
+	return append([]string{"lvrename", "--yes"}, marshalArgs(opts)...)
+}
+
+func (opts *FullReportOptions) commandLine() []string {
+	if opts == nil {
+		opts = &FullReportOptions{}
+	}
+
+	cmdArgs := append([]string{"fullreport", "--reportformat=json", "--binary"}, args.Marshal(opts)...)
+	// Options following --configreport only apply to that subreport, so the raw
+	// arguments come after them to apply to the whole report.
+	cmdArgs = append(cmdArgs,
+		"--configreport=vg", "--options="+defaultVGFields,
+		"--configreport=pv", "--options="+defaultPVFields,
+		"--configreport=lv", "--options=lv_all,vg_name",
+		"--configreport=pvseg", "--options=pvseg_all,pv_name,lv_name,vg_name",
+		"--configreport=seg", "--options=seg_all,lv_name,vg_name",
+	)
+
+	return append(cmdArgs, opts.rawArgs()...)
+}
+
+func (opts GetConfigOptions) commandLine() []string {
+	return append([]string{"lvmconfig"}, marshalArgs(opts)...)
+}
+
+// reportCommandLine returns the arguments for a JSON report command.
+func reportCommandLine(cmd, fields string, optArgs []string) []string {
+	return append([]string{cmd, "--reportformat=json", "--binary", "--options=" + fields}, optArgs...)
+}
+
+// reportFields returns the default fields followed by any requested fields
+// that aren't already included, as the report parsers rely on the defaults.
+func reportFields(defaultFields string, fields []string) string {
+	merged := strings.Split(defaultFields, ",")
+
+	seen := make(map[string]bool)
+	for _, field := range merged {
+		seen[field] = true
+	}
+
+	for _, f := range fields {
+		for _, field := range strings.Split(f, ",") {
+			field = strings.TrimSpace(field)
+			if field != "" && !seen[field] {
+				merged = append(merged, field)
+				seen[field] = true
+			}
+		}
+	}
+
+	return strings.Join(merged, ",")
+}
+
+// marshalArgs converts an options struct into command line arguments. Any raw
+// arguments are appended after the marshaled flags and positional arguments.
+func marshalArgs(opts any) []string {
+	cmdArgs := args.Marshal(opts)
+	if o, ok := opts.(interface{ rawArgs() []string }); ok {
+		cmdArgs = append(cmdArgs, o.rawArgs()...)
+	}
+
+	return cmdArgs
+}
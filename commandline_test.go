@@ -0,0 +1,142 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandLine(t *testing.T) {
+	opts := lvm2.CreateLVOptions{
+		CommonOptions: lvm2.CommonOptions{
+			RawArgs: []string{"--verbose"},
+		},
+		Name:           "lv0",
+		VGName:         "vg0",
+		Size:           "16M",
+		Type:           lvm2.LVTypeRAID1,
+		Mirrors:        lvm2.PtrTo(1),
+		Zero:           lvm2.No,
+		AllocationTags: []string{"ssd"},
+	}
+
+	require.Equal(t, []string{
+		"lvcreate", "--yes", "--name=lv0", "--zero=n", "--type=raid1", "--size=16M", "--mirrors=1", "vg0", "@ssd", "--verbose",
+	}, lvm2.CommandLine(opts))
+
+	t.Log("Report commands")
+
+	require.Equal(t, []string{"lvs", "--reportformat=json", "--binary", "--options=lv_all,seg_all,vg_name"}, lvm2.CommandLine((*lvm2.ListLVOptions)(nil)))
+	require.Equal(t, []string{"vgs", "--reportformat=json", "--binary", "--options=vg_all,vg_tags"}, lvm2.CommandLine(lvm2.ListVGOptions{
+		Options: []string{"vg_all", "vg_tags"},
+	}))
+
+	cmdLine := lvm2.CommandLine(lvm2.FullReportOptions{
+		CommonOptions: lvm2.CommonOptions{
+			RawArgs: []string{"--config=devices/scan_lvs=0"},
+		},
+		VGNames: []string{"vg0"},
+	})
+	require.Equal(t, []string{"fullreport", "--reportformat=json", "--binary", "vg0", "--configreport=vg"}, cmdLine[:5])
+	require.Equal(t, "--config=devices/scan_lvs=0", cmdLine[len(cmdLine)-1])
+
+	active := false
+	require.Equal(t, []string{"lvs", "--reportformat=json", "--binary", "--options=lv_all,seg_all,vg_name", `--select=(lv_size>1g) && lv_active!=active`}, lvm2.CommandLine(&lvm2.ListLVOptions{
+		Select: lvm2.Select().Gt("lv_size", "1g").String(),
+		Active: &active,
+	}))
+
+	t.Log("Devices file commands")
+
+	require.Equal(t, []string{"lvmdevices", "--yes", "--adddev=/dev/sda"}, lvm2.CommandLine(lvm2.AddDeviceOptions{
+		Name: "/dev/sda",
+	}))
+	require.Equal(t, []string{"lvmdevices", "--yes", "--check", "--devicesfile=test.devices"}, lvm2.CommandLine(lvm2.CheckDevicesOptions{
+		CommonOptions: lvm2.CommonOptions{
+			DevicesFile: "test.devices",
+		},
+	}))
+
+	t.Log("Cache settings")
+
+	require.Equal(t, []string{"lvconvert", "--yes", "--type=cache", "--cachevol=fast", "--cachepolicy=smq", "--cachesettings=migration_threshold=2048 sequential_threshold=512", "vg0/lv0"}, lvm2.CommandLine(lvm2.ConvertLVLayoutOptions{
+		Name:        "vg0/lv0",
+		Type:        lvm2.LVTypeCache,
+		CacheVol:    "fast",
+		CachePolicy: "smq",
+		CacheSettings: lvm2.CacheSettings{
+			"sequential_threshold": "512",
+			"migration_threshold":  "2048",
+		},
+	}))
+
+	require.Equal(t, []string{"lvchange", "--yes", "--cachepolicy=smq", "vg0/lv0"}, lvm2.CommandLine(lvm2.UpdateLVOptions{
+		Name:          "vg0/lv0",
+		CachePolicy:   "smq",
+		CacheSettings: lvm2.CacheSettings{},
+	}))
+
+	t.Log("Activation states")
+
+	require.Equal(t, []string{"lvchange", "--yes", "vg0/lv0"}, lvm2.CommandLine(lvm2.UpdateLVOptions{
+		Name: "vg0/lv0",
+	}))
+	require.Equal(t, []string{"vgchange", "--yes", "--activate=n", "vg0"}, lvm2.CommandLine(lvm2.UpdateVGOptions{
+		Name:     "vg0",
+		Activate: lvm2.ActivateNo,
+	}))
+
+	t.Log("Clearing the system ID")
+
+	require.Equal(t, []string{"vgchange", "--yes", "--systemid=", "vg0"}, lvm2.CommandLine(lvm2.UpdateVGOptions{
+		Name:     "vg0",
+		SystemID: lvm2.PtrTo(""),
+	}))
+
+	t.Log("Unsupported options")
+
+	require.PanicsWithValue(t, "lvm2: no command line for lvm2.CloneLVOptions", func() {
+		lvm2.CommandLine(lvm2.CloneLVOptions{})
+	})
+	require.Panics(t, func() {
+		lvm2.CommandLine("lvs")
+	})
+
+	t.Log("JSON round trip")
+
+	data, err := json.Marshal(opts)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"rawArgs": ["--verbose"],
+		"name": "lv0",
+		"vgName": "vg0",
+		"size": "16M",
+		"type": "raid1",
+		"mirrors": 1,
+		"zero": false,
+		"allocationTags": ["ssd"]
+	}`, string(data))
+
+	var decoded lvm2.CreateLVOptions
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, lvm2.CommandLine(opts), lvm2.CommandLine(decoded))
+}
@@ -0,0 +1,175 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseConfig parses the configuration tree printed by lvmconfig into nested
+// maps. Settings are converted to int64, float64, string or []any values.
+func parseConfig(out []byte) (map[string]any, error) {
+	root := make(map[string]any)
+	stack := []map[string]any{root}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		current := stack[len(stack)-1]
+
+		switch {
+		case line == "}":
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("unexpected end of section")
+			}
+			stack = stack[:len(stack)-1]
+		case strings.HasSuffix(line, "{"):
+			name := strings.TrimSpace(strings.TrimSuffix(line, "{"))
+			stack = append(stack, configSection(current, name))
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid configuration line: %q", line)
+			}
+
+			v, err := parseConfigValue(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q: %w", strings.TrimSpace(key), err)
+			}
+			current[strings.TrimSpace(key)] = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("unterminated section")
+	}
+
+	return root, nil
+}
+
+// configSection returns the named subsection of a configuration tree, creating
+// it if it doesn't already exist.
+func configSection(parent map[string]any, name string) map[string]any {
+	if section, ok := parent[name].(map[string]any); ok {
+		return section
+	}
+
+	section := make(map[string]any)
+	parent[name] = section
+	return section
+}
+
+// mergeConfig recursively merges the src configuration tree into dst.
+func mergeConfig(dst, src map[string]any) {
+	for key, value := range src {
+		if section, ok := value.(map[string]any); ok {
+			mergeConfig(configSection(dst, key), section)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func parseConfigValue(s string) (any, error) {
+	switch {
+	case strings.HasPrefix(s, "["):
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("unterminated array: %q", s)
+		}
+
+		values := []any{}
+		for _, item := range splitConfigArray(s[1 : len(s)-1]) {
+			v, err := parseConfigValue(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case strings.HasPrefix(s, `"`):
+		return unquoteConfigString(s)
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, nil
+	}
+
+	return s, nil
+}
+
+// splitConfigArray splits the comma separated items of an array, ignoring any
+// commas within quoted strings.
+func splitConfigArray(s string) []string {
+	var items []string
+	var inQuotes, escaped bool
+
+	start := 0
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ',' && !inQuotes:
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		items = append(items, last)
+	}
+
+	return items
+}
+
+func unquoteConfigString(s string) (string, error) {
+	if len(s) < 2 || !strings.HasSuffix(s, `"`) {
+		return "", fmt.Errorf("unterminated string: %q", s)
+	}
+
+	var sb strings.Builder
+	var escaped bool
+	for _, r := range s[1 : len(s)-1] {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(r)
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,60 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DMName returns the device-mapper name of a logical volume, as found in
+// /dev/mapper. Dashes within the volume group and logical volume names are
+// doubled, and the names are then joined with a single dash.
+func DMName(vgName, lvName string) string {
+	return strings.ReplaceAll(vgName, "-", "--") + "-" + strings.ReplaceAll(lvName, "-", "--")
+}
+
+// ParseDMName splits the device-mapper name of a logical volume into its
+// volume group and logical volume names. It is the inverse of DMName. Names
+// of internal devices with a layer suffix, eg. vg-pool-tpool, are rejected.
+func ParseDMName(name string) (string, string, error) {
+	var parts []string
+
+	start := 0
+	for i := 0; i < len(name); i++ {
+		if name[i] != '-' {
+			continue
+		}
+
+		if i+1 < len(name) && name[i+1] == '-' {
+			// An escaped dash.
+			i++
+			continue
+		}
+
+		parts = append(parts, name[start:i])
+		start = i + 1
+	}
+	parts = append(parts, name[start:])
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid device-mapper name: %q", name)
+	}
+
+	return strings.ReplaceAll(parts[0], "--", "-"), strings.ReplaceAll(parts[1], "--", "-"), nil
+}
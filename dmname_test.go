@@ -0,0 +1,49 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDMName(t *testing.T) {
+	for _, tc := range []struct {
+		vgName, lvName, dmName string
+	}{
+		{"vg0", "lv0", "vg0-lv0"},
+		{"my-vg", "lv0", "my--vg-lv0"},
+		{"vg0", "my-lv", "vg0-my--lv"},
+		{"my--vg", "my--lv", "my----vg-my----lv"},
+		{"a-b-c", "d-e", "a--b--c-d--e"},
+	} {
+		require.Equal(t, tc.dmName, lvm2.DMName(tc.vgName, tc.lvName))
+
+		vgName, lvName, err := lvm2.ParseDMName(tc.dmName)
+		require.NoError(t, err, tc.dmName)
+		require.Equal(t, tc.vgName, vgName)
+		require.Equal(t, tc.lvName, lvName)
+	}
+
+	for _, dmName := range []string{"", "vg0", "my--vg", "-lv0", "vg0-", "vg0-pool-tpool"} {
+		_, _, err := lvm2.ParseDMName(dmName)
+		require.Error(t, err, dmName)
+	}
+}
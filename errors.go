@@ -0,0 +1,94 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrPhysicalVolumeNotFound is returned when a physical volume does not exist.
+	ErrPhysicalVolumeNotFound = errors.New("physical volume not found")
+	// ErrVolumeGroupNotFound is returned when a volume group does not exist.
+	ErrVolumeGroupNotFound = errors.New("volume group not found")
+	// ErrLogicalVolumeNotFound is returned when a logical volume does not exist.
+	ErrLogicalVolumeNotFound = errors.New("logical volume not found")
+	// ErrVDONotSupported is returned when VDO volumes are not supported by lvm
+	// or the kernel.
+	ErrVDONotSupported = errors.New("VDO not supported")
+	// ErrTimeout is returned when an lvm command exceeds the default timeout.
+	ErrTimeout = errors.New("lvm command timed out")
+	// ErrUnavailable is returned when the lvm executable is missing, can't be
+	// run, or fails to respond.
+	ErrUnavailable = errors.New("lvm unavailable")
+	// ErrWouldGrow is returned when a validated reduce would make the logical
+	// volume larger.
+	ErrWouldGrow = errors.New("requested size would grow logical volume")
+	// ErrNoChange is returned when a validated reduce would leave the logical
+	// volume the same size.
+	ErrNoChange = errors.New("requested size is the current size of logical volume")
+	// ErrWouldTruncate is returned when resizing a physical volume would cut
+	// off extents that are allocated to logical volumes.
+	ErrWouldTruncate = errors.New("requested size is smaller than the space allocated on physical volume")
+)
+
+// vdoNotSupportedMessages are the errors lvm reports when VDO support was not
+// compiled in, or the kernel target or vdoformat tool are missing. Other
+// vdoformat failures, eg. for an invalid size, are not included.
+var vdoNotSupportedMessages = []string{
+	"Unknown segment type",
+	"target(s) not detected",
+	"not compiled in",
+	"vdoformat: execvp failed",
+}
+
+// lockContentionMessages are the errors lvm reports when a command fails
+// because another command holds a lock it needs.
+var lockContentionMessages = []string{
+	"Can't get lock",
+	"already locked",
+}
+
+// lvNotFound maps the error lvs reports for a missing LV, or for a missing VG
+// that it would be in, to ErrLogicalVolumeNotFound or ErrVolumeGroupNotFound.
+func lvNotFound(err error, name string) error {
+	vgName, _, _ := strings.Cut(name, "/")
+
+	switch {
+	case errorContains(err, "Failed to find logical volume"):
+		return fmt.Errorf("%w: %s", ErrLogicalVolumeNotFound, name)
+	case errorContains(err, fmt.Sprintf("Volume group %q not found", vgName)):
+		return fmt.Errorf("%w: %s", ErrVolumeGroupNotFound, vgName)
+	default:
+		return err
+	}
+}
+
+// errorContains returns true if the error output of a failed lvm command
+// contains any of the given messages.
+func errorContains(err error, msgs ...string) bool {
+	for _, msg := range msgs {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+
+	return false
+}
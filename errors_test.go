@@ -0,0 +1,76 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateVDOVolumeNotSupported(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  Unknown segment type vdo" >&2
+exit 3`)))
+
+	err := c.CreateLogicalVolume(context.Background(), lvm2.CreateLVOptions{
+		Name:   "vdo",
+		VGName: "vg",
+		Size:   "4G",
+		VDO:    true,
+	})
+	require.ErrorIs(t, err, lvm2.ErrVDONotSupported)
+
+	t.Log("Other volume types are unaffected")
+
+	err = c.CreateLogicalVolume(context.Background(), lvm2.CreateLVOptions{
+		Name:   "linear",
+		VGName: "vg",
+		Size:   "4G",
+	})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, lvm2.ErrVDONotSupported)
+
+	t.Log("A missing vdoformat tool")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  /usr/bin/vdoformat: execvp failed: No such file or directory" >&2
+exit 5`)))
+
+	err = c.CreateLogicalVolume(context.Background(), lvm2.CreateLVOptions{
+		Name:   "vdo",
+		VGName: "vg",
+		Size:   "4G",
+		VDO:    true,
+	})
+	require.ErrorIs(t, err, lvm2.ErrVDONotSupported)
+
+	t.Log("Other vdoformat failures")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  vdoformat: Cannot format device: Device or resource busy" >&2
+exit 5`)))
+
+	err = c.CreateLogicalVolume(context.Background(), lvm2.CreateLVOptions{
+		Name:   "vdo",
+		VGName: "vg",
+		Size:   "4G",
+		VDO:    true,
+	})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, lvm2.ErrVDONotSupported)
+}
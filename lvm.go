@@ -0,0 +1,85 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import "context"
+
+// LVM is the set of operations provided by Client. Consumers can depend on
+// this interface rather than *Client, so that a fake can be substituted in
+// their own tests.
+type LVM interface {
+	ListPhysicalVolumes(ctx context.Context, opts *ListPVOptions) ([]PhysicalVolume, error)
+	GetPhysicalVolume(ctx context.Context, name string) (*PhysicalVolume, error)
+	CreatePhysicalVolume(ctx context.Context, opts CreatePVOptions) error
+	UpdatePhysicalVolume(ctx context.Context, opts UpdatePVOptions) error
+	RemovePhysicalVolume(ctx context.Context, opts RemovePVOptions) error
+	CheckPhysicalVolume(ctx context.Context, opts CheckPVOptions) error
+	CheckPhysicalVolumeWithOutput(ctx context.Context, opts CheckPVOptions) (string, error)
+	MovePhysicalExtents(ctx context.Context, opts MovePEOptions) error
+	MovePhysicalExtentsWithProgress(ctx context.Context, opts MovePEOptions, progress func(percent float64)) error
+	GetPhysicalVolumeMoveStatus(ctx context.Context, pvName string) (MoveStatus, error)
+	ResizePhysicalVolume(ctx context.Context, opts ResizePVOptions) error
+	ScanPhysicalVolumes(ctx context.Context, opts ScanPVOptions) error
+	ListDevices(ctx context.Context) ([]Device, error)
+	ListPhysicalVolumeSegments(ctx context.Context, pvName string) ([]PVSegment, error)
+	AddDevice(ctx context.Context, opts AddDeviceOptions) error
+	RemoveDevice(ctx context.Context, opts RemoveDeviceOptions) error
+	CheckDevices(ctx context.Context, opts CheckDevicesOptions) error
+	ListConfiguredDevices(ctx context.Context, file string) ([]ConfiguredDevice, error)
+	ListVolumeGroups(ctx context.Context, opts *ListVGOptions) ([]VolumeGroup, error)
+	GetVolumeGroup(ctx context.Context, name string) (*VolumeGroup, error)
+	AvailableExtents(ctx context.Context, vgName string) (uint64, Size, error)
+	AvailableBytes(ctx context.Context, vgName string) (Size, error)
+	ResolveSize(ctx context.Context, vgName, spec string) (Size, error)
+	CreateVolumeGroup(ctx context.Context, opts CreateVGOptions) error
+	UpdateVolumeGroup(ctx context.Context, opts UpdateVGOptions) error
+	RemoveVolumeGroup(ctx context.Context, opts RemoveVGOptions) error
+	CheckVolumeGroup(ctx context.Context, opts CheckVGOptions) error
+	ExportVolumeGroup(ctx context.Context, opts ExportVGOptions) error
+	ImportVolumeGroup(ctx context.Context, opts ImportVGOptions) error
+	ImportVolumeGroupFromCloned(ctx context.Context, opts ImportVGFromClonedOptions) error
+	MergeVolumeGroups(ctx context.Context, opts MergeVGOptions) error
+	ExtendVolumeGroup(ctx context.Context, opts ExtendVGOptions) error
+	ReduceVolumeGroup(ctx context.Context, opts ReduceVGOptions) error
+	RenameVolumeGroup(ctx context.Context, opts RenameVGOptions) error
+	MovePhysicalVolumes(ctx context.Context, opts MovePVOptions) error
+	MakeVolumeGroupDeviceNodes(ctx context.Context, opts MakeVGDeviceNodesOptions) error
+	ListLogicalVolumes(ctx context.Context, opts *ListLVOptions) ([]LogicalVolume, error)
+	IterLogicalVolumes(ctx context.Context, opts *ListLVOptions, fn func(LogicalVolume) error) error
+	GetLogicalVolume(ctx context.Context, name string) (*LogicalVolume, error)
+	CreateLogicalVolume(ctx context.Context, opts CreateLVOptions) error
+	CreateLogicalVolumeWithOutput(ctx context.Context, opts CreateLVOptions) (string, error)
+	UpdateLogicalVolume(ctx context.Context, opts UpdateLVOptions) error
+	WaitForDeviceNode(ctx context.Context, vgName, lvName string) (string, error)
+	ScrubLogicalVolume(ctx context.Context, opts ScrubLVOptions) error
+	RemoveLogicalVolume(ctx context.Context, opts RemoveLVOptions) error
+	ConvertLogicalVolumeLayout(ctx context.Context, opts ConvertLVLayoutOptions) error
+	ExtendLogicalVolume(ctx context.Context, opts ExtendLVOptions) error
+	ReduceLogicalVolume(ctx context.Context, opts ReduceLVOptions) error
+	RenameLogicalVolume(ctx context.Context, opts RenameLVOptions) error
+	CloneLogicalVolume(ctx context.Context, opts CloneLVOptions) error
+	FullReport(ctx context.Context, opts *FullReportOptions) (*Report, error)
+	GetConfig(ctx context.Context, opts GetConfigOptions) (map[string]any, error)
+	SetThinPoolAutoextend(ctx context.Context, vgName string, threshold, percent int) error
+	ClearThinPoolAutoextend(ctx context.Context, vgName string) error
+	Version(ctx context.Context) (Version, error)
+	Available(ctx context.Context) error
+	Watch(ctx context.Context) (<-chan Event, error)
+}
+
+var _ LVM = (*Client)(nil)
@@ -18,17 +18,38 @@
 package lvm2
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
 	"os/exec"
-
-	"github.com/dpeckett/args"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
+// Client runs lvm2 commands. It is safe for concurrent use, although concurrent
+// commands may fail while contending for lvm's own locks, see
+// WithSerializedCommands.
 type Client struct {
-	lvmPath string
+	lvmPath        string
+	defaultTimeout time.Duration
+	devicesFile    string
+	retryAttempts  int
+	retryBackoff   time.Duration
+	warningHandler func(string)
+	foreign        bool
+	shared         bool
+	noAutoConfirm  bool
+	noLocking      bool
+	watchInterval  time.Duration
+	// mu serializes commands, when enabled with WithSerializedCommands.
+	mu *sync.Mutex
 }
 
 // Construct a new lvm2 client.
@@ -46,93 +67,231 @@ func NewClient(opts ...ClientOption) *Client {
 
 // Display attributes of a physical volume/s.
 func (c *Client) ListPhysicalVolumes(ctx context.Context, opts *ListPVOptions) ([]PhysicalVolume, error) {
-	cmdArgs := []string{"pvs", "--reportformat=json", "--binary", "--options=pv_all,vg_name"}
-	if opts != nil {
-		cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-	}
-
-	reportJSON, err := c.run(ctx, cmdArgs...)
+	reportJSON, err := c.run(ctx, opts.commandLine()...)
 	if err != nil {
 		return nil, err
 	}
 
-	var report struct {
-		Report []struct {
-			PV []PhysicalVolume `json:"pv"`
-		} `json:"report"`
-	}
-	if err := json.Unmarshal(reportJSON, &report); err != nil {
-		return nil, fmt.Errorf("failed to parse lvm output: %w", err)
+	return ParsePVReport(reportJSON)
+}
+
+// Get a single physical volume by name.
+func (c *Client) GetPhysicalVolume(ctx context.Context, name string) (*PhysicalVolume, error) {
+	pvs, err := c.ListPhysicalVolumes(ctx, &ListPVOptions{
+		Names: []string{name},
+	})
+	if err != nil {
+		if errorContains(err, "Failed to find physical volume") {
+			return nil, fmt.Errorf("%w: %s", ErrPhysicalVolumeNotFound, name)
+		}
+
+		return nil, err
 	}
 
-	if len(report.Report) > 0 && len(report.Report[0].PV) > 0 {
-		return report.Report[0].PV, nil
+	if len(pvs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPhysicalVolumeNotFound, name)
 	}
 
-	return nil, nil
+	return &pvs[0], nil
 }
 
 // Create a new physical volume on a device.
 func (c *Client) CreatePhysicalVolume(ctx context.Context, opts CreatePVOptions) error {
-	cmdArgs := []string{"pvcreate", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Change physical volume attributes.
 func (c *Client) UpdatePhysicalVolume(ctx context.Context, opts UpdatePVOptions) error {
-	cmdArgs := []string{"pvchange", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Remove a physical volume from a device.
 func (c *Client) RemovePhysicalVolume(ctx context.Context, opts RemovePVOptions) error {
-	cmdArgs := []string{"pvremove", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Check / repair physical volume metadata.
 func (c *Client) CheckPhysicalVolume(ctx context.Context, opts CheckPVOptions) error {
-	cmdArgs := []string{"pvck", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.CheckPhysicalVolumeWithOutput(ctx, opts)
 	return err
 }
 
+// Check / repair physical volume metadata, returning the output of the check,
+// eg. the headers or metadata requested with the Dump option.
+func (c *Client) CheckPhysicalVolumeWithOutput(ctx context.Context, opts CheckPVOptions) (string, error) {
+	out, err := c.run(ctx, opts.commandLine()...)
+	return string(out), err
+}
+
 // Move extents from one physical volume to another.
 func (c *Client) MovePhysicalExtents(ctx context.Context, opts MovePEOptions) error {
-	cmdArgs := []string{"pvmove", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
+	_, err := c.run(ctx, opts.commandLine()...)
+	return err
+}
+
+// moveAbortTimeout bounds how long aborting an interrupted move may take.
+const moveAbortTimeout = time.Minute
+
+// Move extents from one physical volume to another, reporting the percentage
+// moved as the move progresses. If the context is done, or the command exceeds
+// the default timeout, the move is aborted.
+func (c *Client) MovePhysicalExtentsWithProgress(ctx context.Context, opts MovePEOptions, progress func(percent float64)) error {
+	if opts.Background {
+		return fmt.Errorf("progress cannot be reported for background moves")
+	}
+
+	if opts.Interval == nil {
+		opts.Interval = PtrTo(1)
+	}
+
+	stdout, err := c.runStream(ctx, opts.commandLine()...)
+	if err != nil {
+		return err
+	}
+
+	err = readProgress(stdout, progress)
+	if closeErr := stdout.Close(); closeErr != nil {
+		err = closeErr
+	}
+	if err != nil && (ctx.Err() != nil || errors.Is(err, ErrTimeout)) {
+		// Killing pvmove leaves the move in progress, so it needs to be aborted.
+		// The context is done, so the abort gets a context of its own.
+		abortCtx, cancel := context.WithTimeout(context.Background(), moveAbortTimeout)
+		defer cancel()
+
+		abortOpts := MovePEOptions{
+			CommonOptions: opts.CommonOptions,
+			Source:        opts.Source,
+			Abort:         true,
+		}
+		if _, abortErr := c.run(abortCtx, abortOpts.commandLine()...); abortErr != nil {
+			return fmt.Errorf("failed to abort move: %w", abortErr)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
 
-	_, err := c.run(ctx, cmdArgs...)
 	return err
 }
 
-// Resize a physical volume.
+// Get the status of a physical extent move from a physical volume, eg. one
+// started in the background.
+func (c *Client) GetPhysicalVolumeMoveStatus(ctx context.Context, pvName string) (MoveStatus, error) {
+	movePV := Select().Eq("move_pv", pvName)
+	if err := movePV.Err(); err != nil {
+		return MoveStatus{}, err
+	}
+
+	lvs, err := c.ListLogicalVolumes(ctx, &ListLVOptions{
+		All:    true,
+		Select: movePV.String(),
+	})
+	if err != nil {
+		return MoveStatus{}, err
+	}
+
+	if len(lvs) == 0 {
+		return MoveStatus{}, nil
+	}
+
+	return MoveStatus{
+		InProgress: true,
+		LVName:     lvs[0].Name,
+		Percent:    lvs[0].CopyPercent.Float64,
+	}, nil
+}
+
+// Resize a physical volume. Unless opts.Force is set, ErrWouldTruncate is
+// returned if the requested size is smaller than the space allocated on it.
 func (c *Client) ResizePhysicalVolume(ctx context.Context, opts ResizePVOptions) error {
-	cmdArgs := []string{"pvresize", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
+	if opts.SetPhysicalVolumeSize != "" && !opts.Force {
+		if err := c.validatePVResize(ctx, opts); err != nil {
+			return err
+		}
+	}
 
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
-// Display volume group/s information.
-func (c *Client) ListVolumeGroups(ctx context.Context, opts *ListVGOptions) ([]VolumeGroup, error) {
-	cmdArgs := []string{"vgs", "--reportformat=json", "--binary", "--options=vg_all"}
-	if opts != nil {
-		cmdArgs = append(cmdArgs, args.Marshal(opts)...)
+// validatePVResize checks that the size requested for pvresize leaves room for
+// the data area offset and every allocated extent. Extents aren't necessarily
+// allocated from the start of the PV, so this is the end of the last allocated
+// segment rather than the amount of space used.
+func (c *Client) validatePVResize(ctx context.Context, opts ResizePVOptions) error {
+	size, err := parseSizeArg(opts.SetPhysicalVolumeSize)
+	if err != nil {
+		return err
+	}
+
+	pvs, err := c.ListPhysicalVolumes(ctx, &ListPVOptions{
+		CommonOptions: opts.CommonOptions.withByteUnits(),
+		Names:         []string{opts.Name},
+	})
+	if err != nil {
+		if errorContains(err, "Failed to find physical volume") {
+			return fmt.Errorf("%w: %s", ErrPhysicalVolumeNotFound, opts.Name)
+		}
+
+		return err
+	}
+
+	if len(pvs) == 0 {
+		return fmt.Errorf("%w: %s", ErrPhysicalVolumeNotFound, opts.Name)
+	}
+
+	needed, err := ParseSize(pvs[0].ExtentStart)
+	if err != nil {
+		return fmt.Errorf("failed to parse data area offset: %w", err)
+	}
+
+	segments, err := c.listPVSegments(ctx, opts.CommonOptions, opts.Name)
+	if err != nil {
+		return err
+	}
+
+	var end int
+	for _, seg := range segments {
+		if !seg.Free() && int(seg.Start+seg.Length) > end {
+			end = int(seg.Start + seg.Length)
+		}
+	}
+
+	if end > 0 {
+		extentSize, err := ParseSize(pvs[0].ExtentSize)
+		if err != nil {
+			return fmt.Errorf("failed to parse extent size: %w", err)
+		}
+
+		needed += Size(end) * extentSize
+	}
+
+	if size < needed {
+		return fmt.Errorf("%w: %s needs at least %s, requested %s", ErrWouldTruncate, opts.Name, needed, size)
+	}
+
+	return nil
+}
+
+// Scan all devices for physical volumes, optionally updating the online cache.
+func (c *Client) ScanPhysicalVolumes(ctx context.Context, opts ScanPVOptions) error {
+	if opts.Activate != "" && opts.Activate != ActivateAuto {
+		return fmt.Errorf("pvscan only supports autoactivation: %q", opts.Activate)
 	}
 
+	_, err := c.run(ctx, opts.commandLine()...)
+	return err
+}
+
+// List block devices that could be used as physical volumes.
+func (c *Client) ListDevices(ctx context.Context) ([]Device, error) {
+	cmdArgs := []string{"pvs", "--reportformat=json", "--binary", "--all", "--options=pv_name,dev_size,pv_uuid"}
+
 	reportJSON, err := c.run(ctx, cmdArgs...)
 	if err != nil {
 		return nil, err
@@ -140,239 +299,963 @@ func (c *Client) ListVolumeGroups(ctx context.Context, opts *ListVGOptions) ([]V
 
 	var report struct {
 		Report []struct {
-			VG []VolumeGroup `json:"vg"`
+			PV []struct {
+				Name       string `json:"pv_name"`
+				DeviceSize string `json:"dev_size"`
+				UUID       string `json:"pv_uuid"`
+			} `json:"pv"`
 		} `json:"report"`
 	}
 	if err := json.Unmarshal(reportJSON, &report); err != nil {
-		return nil, fmt.Errorf("failed to parse lvm output: %w", err)
+		return nil, parseError(err, reportJSON)
 	}
 
-	if len(report.Report) > 0 && len(report.Report[0].VG) > 0 {
-		return report.Report[0].VG, nil
+	if len(report.Report) == 0 {
+		return nil, nil
+	}
+
+	var devices []Device
+	for _, pv := range report.Report[0].PV {
+		devices = append(devices, Device{
+			Path: pv.Name,
+			Size: pv.DeviceSize,
+			IsPV: pv.UUID != "",
+		})
+	}
+
+	return devices, nil
+}
+
+// List the segments of a physical volume, ie. the ranges of extents that are
+// free or allocated to each logical volume, in order.
+func (c *Client) ListPhysicalVolumeSegments(ctx context.Context, pvName string) ([]PVSegment, error) {
+	return c.listPVSegments(ctx, CommonOptions{}, pvName)
+}
+
+func (c *Client) listPVSegments(ctx context.Context, commonOpts CommonOptions, pvName string) ([]PVSegment, error) {
+	cmdArgs := append([]string{"pvs", "--reportformat=json", "--binary", "--segments", "--options=pvseg_all,pv_name,lv_name,vg_name"}, marshalArgs(struct {
+		CommonOptions
+		Name string `arg:"0"`
+	}{commonOpts, pvName})...)
+
+	reportJSON, err := c.run(ctx, cmdArgs...)
+	if err != nil {
+		if errorContains(err, "Failed to find physical volume") {
+			return nil, fmt.Errorf("%w: %s", ErrPhysicalVolumeNotFound, pvName)
+		}
+
+		return nil, err
 	}
 
-	return nil, nil
+	return parseReport[PVSegment](reportJSON, "pv")
+}
+
+// Add a device to the devices file, so that lvm commands will use it.
+func (c *Client) AddDevice(ctx context.Context, opts AddDeviceOptions) error {
+	_, err := c.run(ctx, opts.commandLine()...)
+	return err
+}
+
+// Remove a device from the devices file, so that lvm commands will ignore it.
+func (c *Client) RemoveDevice(ctx context.Context, opts RemoveDeviceOptions) error {
+	_, err := c.run(ctx, opts.commandLine()...)
+	return err
+}
+
+// Check the entries in the devices file against the devices on the system.
+func (c *Client) CheckDevices(ctx context.Context, opts CheckDevicesOptions) error {
+	_, err := c.run(ctx, opts.commandLine()...)
+	return err
+}
+
+// List the devices in the given devices file (from /etc/lvm/devices/). If the
+// file is empty, the client's devices file is used, or else the system
+// devices file.
+func (c *Client) ListConfiguredDevices(ctx context.Context, file string) ([]ConfiguredDevice, error) {
+	cmdArgs := []string{"lvmdevices"}
+	if file != "" {
+		cmdArgs = append(cmdArgs, "--devicesfile="+file)
+	}
+
+	out, err := c.run(ctx, cmdArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseConfiguredDevices(out), nil
+}
+
+// parseConfiguredDevices parses the output of lvmdevices, which lists each
+// entry as key=value pairs, eg.
+//
+//	Device /dev/sdb IDTYPE=sys_wwid IDNAME=naa.6001405 DEVNAME=/dev/sdb PVID=none
+func parseConfiguredDevices(out []byte) []ConfiguredDevice {
+	var devices []ConfiguredDevice
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "Device" {
+			continue
+		}
+
+		var dev ConfiguredDevice
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+
+			switch key {
+			case "IDTYPE":
+				dev.IDType = value
+			case "IDNAME":
+				dev.IDName = value
+			case "DEVNAME":
+				dev.DevName = value
+			case "PVID":
+				// Devices that aren't PVs are listed with a placeholder PVID.
+				if value != "none" && value != "." {
+					dev.PVID = value
+				}
+			}
+		}
+
+		devices = append(devices, dev)
+	}
+
+	return devices
+}
+
+// Display volume group/s information.
+func (c *Client) ListVolumeGroups(ctx context.Context, opts *ListVGOptions) ([]VolumeGroup, error) {
+	reportJSON, err := c.run(ctx, opts.commandLine()...)
+	if err != nil {
+		return nil, err
+	}
+
+	vgs, err := ParseVGReport(reportJSON)
+	if err != nil || len(vgs) == 0 {
+		return vgs, err
+	}
+
+	vgNames := make([]string, len(vgs))
+	for i, vg := range vgs {
+		vgNames[i] = vg.Name
+	}
+
+	// The spares are only looked up on a best-effort basis, so that a failure
+	// doesn't lose the VGs that were listed.
+	if lvs, err := c.listLVRoles(ctx, opts, vgNames); err == nil {
+		setPoolMetadataSpares(vgs, lvs)
+	}
+
+	return vgs, nil
+}
+
+// listLVRoles lists the names and roles of all the LVs in the given VGs,
+// including hidden ones such as pool metadata spares. The VGs are named
+// explicitly, so the foreign and shared flags are needed to list their LVs, but
+// the raw arguments are for vgs and aren't passed on.
+func (c *Client) listLVRoles(ctx context.Context, vgOpts *ListVGOptions, vgNames []string) ([]LogicalVolume, error) {
+	lvOpts := struct {
+		CommonOptions
+		VGNames []string `arg:"0"`
+		Foreign bool     `arg:"foreign"`
+		Shared  bool     `arg:"shared"`
+	}{VGNames: vgNames}
+	if vgOpts != nil {
+		lvOpts.CommonOptions = vgOpts.CommonOptions
+		lvOpts.RawArgs = nil
+		lvOpts.Foreign = vgOpts.Foreign
+		lvOpts.Shared = vgOpts.Shared
+	}
+
+	cmdArgs := append([]string{"lvs", "--reportformat=json", "--binary", "--all", "--options=lv_name,lv_role,vg_name"}, marshalArgs(lvOpts)...)
+
+	reportJSON, err := c.run(ctx, cmdArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseLVReport(reportJSON)
+}
+
+// setPoolMetadataSpares sets PoolMetadataSpare on the VGs that have a pool
+// metadata spare LV.
+func setPoolMetadataSpares(vgs []VolumeGroup, lvs []LogicalVolume) {
+	spares := make(map[string]bool)
+	for _, lv := range lvs {
+		for _, role := range strings.Split(lv.Role, ",") {
+			if role == "spare" {
+				spares[lv.VGName] = true
+			}
+		}
+	}
+
+	for i := range vgs {
+		vgs[i].PoolMetadataSpare = spares[vgs[i].Name]
+	}
+}
+
+// Get a single volume group by name.
+func (c *Client) GetVolumeGroup(ctx context.Context, name string) (*VolumeGroup, error) {
+	vgs, err := c.ListVolumeGroups(ctx, &ListVGOptions{
+		Names: []string{name},
+	})
+	if err != nil {
+		if errorContains(err, fmt.Sprintf("Volume group %q not found", name)) {
+			return nil, fmt.Errorf("%w: %s", ErrVolumeGroupNotFound, name)
+		}
+
+		return nil, err
+	}
+
+	if len(vgs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrVolumeGroupNotFound, name)
+	}
+
+	return &vgs[0], nil
+}
+
+// Get the number of free extents in a volume group, and the size of each
+// extent. This is the largest linear logical volume that can be created.
+func (c *Client) AvailableExtents(ctx context.Context, vgName string) (uint64, Size, error) {
+	vg, err := c.GetVolumeGroup(ctx, vgName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	extentSize, err := ParseSize(vg.ExtentSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse extent size: %w", err)
+	}
+
+	return uint64(vg.ExtentFreeCount), extentSize, nil
+}
+
+// Get the free space in a volume group, in whole extents.
+func (c *Client) AvailableBytes(ctx context.Context, vgName string) (Size, error) {
+	count, extentSize, err := c.AvailableExtents(ctx, vgName)
+	if err != nil {
+		return 0, err
+	}
+
+	return Size(count) * extentSize, nil
+}
+
+// Resolve a size, as given to lvcreate, to the number of bytes it would
+// allocate in a volume group. The size is either absolute, eg. "2G", which is
+// rounded up to a whole number of extents, or a percentage of the volume
+// group, eg. "50%FREE" or "100%VG".
+func (c *Client) ResolveSize(ctx context.Context, vgName, spec string) (Size, error) {
+	vg, err := c.GetVolumeGroup(ctx, vgName)
+	if err != nil {
+		return 0, err
+	}
+
+	extentSize, err := ParseSize(vg.ExtentSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse extent size: %w", err)
+	}
+
+	if extentSize == 0 {
+		return 0, fmt.Errorf("invalid extent size: %q", vg.ExtentSize)
+	}
+
+	if percent, of, ok := strings.Cut(spec, "%"); ok {
+		n, err := strconv.ParseUint(percent, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid percentage: %q", spec)
+		}
+
+		var count uint64
+		switch strings.ToUpper(of) {
+		case "FREE":
+			count = uint64(vg.ExtentFreeCount)
+		case "VG":
+			count = uint64(vg.ExtentCount)
+		default:
+			return 0, fmt.Errorf("unsupported percentage of %q: %q", of, spec)
+		}
+
+		// lvm rounds percentages down to a whole number of extents.
+		return Size(n*count/100) * extentSize, nil
+	}
+
+	size, err := parseSizeArg(spec)
+	if err != nil {
+		return 0, err
+	}
+
+	extents := (size + extentSize - 1) / extentSize
+
+	return extents * extentSize, nil
 }
 
 // Create a new volume group.
 func (c *Client) CreateVolumeGroup(ctx context.Context, opts CreateVGOptions) error {
-	cmdArgs := []string{"vgcreate", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Change volume group attributes.
 func (c *Client) UpdateVolumeGroup(ctx context.Context, opts UpdateVGOptions) error {
-	cmdArgs := []string{"vgchange", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Remove a volume group.
 func (c *Client) RemoveVolumeGroup(ctx context.Context, opts RemoveVGOptions) error {
-	cmdArgs := []string{"vgremove", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Check / repair volume group metadata.
 func (c *Client) CheckVolumeGroup(ctx context.Context, opts CheckVGOptions) error {
-	cmdArgs := []string{"vgck", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Unregister a volume group from the system.
 func (c *Client) ExportVolumeGroup(ctx context.Context, opts ExportVGOptions) error {
-	cmdArgs := []string{"vgexport", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Register a volume group with the system.
 func (c *Client) ImportVolumeGroup(ctx context.Context, opts ImportVGOptions) error {
-	cmdArgs := []string{"vgimport", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Import a volume group from cloned physical volumes.
 func (c *Client) ImportVolumeGroupFromCloned(ctx context.Context, opts ImportVGFromClonedOptions) error {
-	cmdArgs := []string{"vgimportclone", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Merge volume groups.
 func (c *Client) MergeVolumeGroups(ctx context.Context, opts MergeVGOptions) error {
-	cmdArgs := []string{"vgmerge", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Add physical volumes to a volume group.
 func (c *Client) ExtendVolumeGroup(ctx context.Context, opts ExtendVGOptions) error {
-	cmdArgs := []string{"vgextend", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Remove physical volumes from a volume group.
 func (c *Client) ReduceVolumeGroup(ctx context.Context, opts ReduceVGOptions) error {
-	cmdArgs := []string{"vgreduce", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Rename a volume group.
 func (c *Client) RenameVolumeGroup(ctx context.Context, opts RenameVGOptions) error {
-	cmdArgs := []string{"vgrename", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Move physical volumes between volume groups.
 func (c *Client) MovePhysicalVolumes(ctx context.Context, opts MovePVOptions) error {
-	cmdArgs := []string{"vgsplit", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Create device files for active logical volumes in the volume group.
 func (c *Client) MakeVolumeGroupDeviceNodes(ctx context.Context, opts MakeVGDeviceNodesOptions) error {
-	cmdArgs := []string{"vgmknodes", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Display logical volume/s information.
 func (c *Client) ListLogicalVolumes(ctx context.Context, opts *ListLVOptions) ([]LogicalVolume, error) {
-	cmdArgs := []string{"lvs", "--reportformat=json", "--binary", "--options=lv_all,seg_all,vg_name"}
-	if opts != nil {
-		cmdArgs = append(cmdArgs, args.Marshal(opts)...)
+	reportJSON, err := c.run(ctx, opts.commandLine()...)
+	if err != nil {
+		return nil, err
 	}
 
-	reportJSON, err := c.run(ctx, cmdArgs...)
+	return ParseLVReport(reportJSON)
+}
+
+// Iterate over logical volumes as they are read from the report, rather than
+// loading them all into memory. Iteration stops at the first error returned
+// by fn, which is then returned.
+// When commands are serialized, fn must not call back into the client.
+func (c *Client) IterLogicalVolumes(ctx context.Context, opts *ListLVOptions, fn func(LogicalVolume) error) error {
+	stdout, err := c.runStream(ctx, opts.commandLine()...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var report struct {
-		Report []struct {
-			LV []LogicalVolume `json:"lv"`
-		} `json:"report"`
+	err = decodeReport(stdout, "lv", func(dec *json.Decoder) error {
+		var lv LogicalVolume
+		if err := dec.Decode(&lv); err != nil {
+			return fmt.Errorf("failed to parse lvm output: %w", err)
+		}
+
+		return fn(lv)
+	})
+	if err != nil {
+		// Closing kills the command if its output wasn't read to the end, so
+		// only its error is of interest if it exited on its own.
+		if closeErr := stdout.Close(); closeErr != nil && !errors.Is(closeErr, errAbandoned) {
+			return closeErr
+		}
+
+		return err
 	}
-	if err := json.Unmarshal(reportJSON, &report); err != nil {
-		return nil, fmt.Errorf("failed to parse lvm output: %w", err)
+
+	_, _ = io.Copy(io.Discard, stdout)
+
+	return stdout.Close()
+}
+
+// Get a single logical volume by name (in the form vg/lv), with each of its
+// segments in Segments.
+func (c *Client) GetLogicalVolume(ctx context.Context, name string) (*LogicalVolume, error) {
+	lvs, err := c.ListLogicalVolumes(ctx, &ListLVOptions{
+		Names: []string{name},
+	})
+	if err != nil {
+		return nil, lvNotFound(err, name)
 	}
 
-	if len(report.Report) > 0 && len(report.Report[0].LV) > 0 {
-		return report.Report[0].LV, nil
+	if len(lvs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLogicalVolumeNotFound, name)
 	}
 
-	return nil, nil
+	// The report has a row per segment, so collapse them into the LV.
+	lv := lvs[0]
+	for _, row := range lvs {
+		if row.UUID == lv.UUID {
+			lv.Segments = append(lv.Segments, row)
+		}
+	}
+
+	return &lv, nil
 }
 
 // Create a new logical volume in a volume group.
 func (c *Client) CreateLogicalVolume(ctx context.Context, opts CreateLVOptions) error {
-	cmdArgs := []string{"lvcreate", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.CreateLogicalVolumeWithOutput(ctx, opts)
 	return err
 }
 
+// Create a new logical volume in a volume group, returning the messages that
+// lvcreate printed, eg. `Logical volume "lv0" created.`
+func (c *Client) CreateLogicalVolumeWithOutput(ctx context.Context, opts CreateLVOptions) (string, error) {
+	// PVNames follow the VG name, so can't be given without it.
+	if opts.VGName == "" {
+		return "", fmt.Errorf("volume group name is required to create logical volume: %q", opts.Name)
+	}
+
+	out, err := c.run(ctx, opts.commandLine()...)
+	if err != nil && isVDO(opts) && errorContains(err, vdoNotSupportedMessages...) {
+		return "", fmt.Errorf("%w: %w", ErrVDONotSupported, err)
+	}
+
+	return string(out), err
+}
+
+func isVDO(opts CreateLVOptions) bool {
+	return opts.VDO || opts.VDOPool != "" || opts.Type == LVTypeVDO || opts.Type == LVTypeVDOPool
+}
+
 // Change logical volume attributes.
 func (c *Client) UpdateLogicalVolume(ctx context.Context, opts UpdateLVOptions) error {
-	cmdArgs := []string{"lvchange", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
+	_, err := c.run(ctx, opts.commandLine()...)
+	return err
+}
 
-	_, err := c.run(ctx, cmdArgs...)
+// Wait for the device node of an activated logical volume to appear, as udev
+// may not have created it by the time the activation command returns. Returns
+// the path to the device node.
+func (c *Client) WaitForDeviceNode(ctx context.Context, vgName, lvName string) (string, error) {
+	devPath := path.Join("/dev", vgName, lvName)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var madeNodes bool
+	for {
+		if _, err := os.Stat(devPath); err == nil {
+			return devPath, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		if !madeNodes {
+			// Create any missing nodes ourselves rather than relying on udev.
+			if err := c.MakeVolumeGroupDeviceNodes(ctx, MakeVGDeviceNodesOptions{
+				Name: vgName + "/" + lvName,
+			}); err != nil {
+				return "", fmt.Errorf("failed to make device nodes: %w", err)
+			}
+			madeNodes = true
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("device node %s did not appear: %w", devPath, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start a background consistency check or repair of a RAID logical volume.
+func (c *Client) ScrubLogicalVolume(ctx context.Context, opts ScrubLVOptions) error {
+	if opts.Action != ScrubCheck && opts.Action != ScrubRepair {
+		return fmt.Errorf("invalid scrub action: %q", opts.Action)
+	}
+
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Remove a logical volume.
 func (c *Client) RemoveLogicalVolume(ctx context.Context, opts RemoveLVOptions) error {
-	cmdArgs := []string{"lvremove", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Change logical volume layout.
 func (c *Client) ConvertLogicalVolumeLayout(ctx context.Context, opts ConvertLVLayoutOptions) error {
-	cmdArgs := []string{"lvconvert", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
 // Add space to a logical volume.
 func (c *Client) ExtendLogicalVolume(ctx context.Context, opts ExtendLVOptions) error {
-	cmdArgs := []string{"lvextend", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
-
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
-// Reduce the size of a logical volume.
+// Reduce the size of a logical volume. If opts.Validate is set, the current
+// size of the LV is fetched first, and ErrWouldGrow or ErrNoChange is returned
+// if the requested size wouldn't shrink it.
 func (c *Client) ReduceLogicalVolume(ctx context.Context, opts ReduceLVOptions) error {
-	cmdArgs := []string{"lvreduce", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
+	if opts.Validate {
+		if err := c.validateReduce(ctx, opts); err != nil {
+			return err
+		}
+	}
 
-	_, err := c.run(ctx, cmdArgs...)
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
+// validateReduce checks that the size requested for lvreduce is smaller than
+// the current size of the LV. Sizes given in extents are not validated.
+func (c *Client) validateReduce(ctx context.Context, opts ReduceLVOptions) error {
+	if opts.Size == "" {
+		return nil
+	}
+
+	if delta, ok := strings.CutPrefix(opts.Size, "-"); ok {
+		size, err := parseSizeArg(delta)
+		if err != nil {
+			return err
+		}
+
+		if size == 0 {
+			return fmt.Errorf("%w: %s", ErrNoChange, opts.Name)
+		}
+
+		return nil
+	}
+
+	if delta, ok := strings.CutPrefix(opts.Size, "+"); ok {
+		size, err := parseSizeArg(delta)
+		if err != nil {
+			return err
+		}
+
+		if size == 0 {
+			return fmt.Errorf("%w: %s", ErrNoChange, opts.Name)
+		}
+
+		return fmt.Errorf("%w: %s", ErrWouldGrow, opts.Name)
+	}
+
+	size, err := parseSizeArg(opts.Size)
+	if err != nil {
+		return err
+	}
+
+	lvs, err := c.ListLogicalVolumes(ctx, &ListLVOptions{
+		CommonOptions: opts.CommonOptions.withByteUnits(),
+		Names:         []string{opts.Name},
+	})
+	if err != nil {
+		return lvNotFound(err, opts.Name)
+	}
+
+	if len(lvs) == 0 {
+		return fmt.Errorf("%w: %s", ErrLogicalVolumeNotFound, opts.Name)
+	}
+
+	current, err := ParseSize(lvs[0].Size)
+	if err != nil {
+		return fmt.Errorf("failed to parse logical volume size: %w", err)
+	}
+
+	switch {
+	case size > current:
+		return fmt.Errorf("%w: %s is %s, requested %s", ErrWouldGrow, opts.Name, current, size)
+	case size == current:
+		return fmt.Errorf("%w: %s", ErrNoChange, opts.Name)
+	default:
+		return nil
+	}
+}
+
 // Rename a logical volume.
 func (c *Client) RenameLogicalVolume(ctx context.Context, opts RenameLVOptions) error {
-	cmdArgs := []string{"lvrename", "--yes"}
-	cmdArgs = append(cmdArgs, args.Marshal(opts)...)
+	vgName, _, ok := strings.Cut(opts.From, "/")
+	if !ok {
+		return fmt.Errorf("logical volume to rename must be in the form vg/lv: %q", opts.From)
+	}
 
-	_, err := c.run(ctx, cmdArgs...)
+	if toVGName, _, ok := strings.Cut(opts.To, "/"); ok && toVGName != vgName {
+		return fmt.Errorf("cannot rename logical volume %q to %q in a different volume group", opts.From, opts.To)
+	}
+
+	_, err := c.run(ctx, opts.commandLine()...)
 	return err
 }
 
+// Clone a logical volume by taking a snapshot of it. Thin volumes are cloned
+// with a thin snapshot, while clones of thick volumes need space reserved for
+// changes.
+func (c *Client) CloneLogicalVolume(ctx context.Context, opts CloneLVOptions) error {
+	origin, err := c.GetLogicalVolume(ctx, opts.Origin)
+	if err != nil {
+		return err
+	}
+
+	createOpts := CreateLVOptions{
+		CommonOptions: opts.CommonOptions,
+		Name:          opts.Name,
+		VGName:        opts.Origin,
+		Snapshot:      true,
+	}
+
+	if origin.Type == LVTypeThin {
+		if opts.Independent {
+			// Thin snapshots are skipped on activation by default.
+			createOpts.SetActivationSkip = No
+			createOpts.Activate = ActivateYes
+		}
+	} else {
+		if opts.Independent {
+			return fmt.Errorf("only clones of thin volumes can be independent: %q", opts.Origin)
+		}
+
+		if opts.Size == "" {
+			return fmt.Errorf("size is required to clone thick volume: %q", opts.Origin)
+		}
+		createOpts.Size = opts.Size
+	}
+
+	return c.CreateLogicalVolume(ctx, createOpts)
+}
+
+// Report on physical volumes, volume groups, logical volumes and their
+// segments in a single command.
+func (c *Client) FullReport(ctx context.Context, opts *FullReportOptions) (*Report, error) {
+	reportJSON, err := c.run(ctx, opts.commandLine()...)
+	if err != nil {
+		return nil, err
+	}
+
+	// There is a separate report for each volume group.
+	var reports struct {
+		Report []Report `json:"report"`
+	}
+	if err := json.Unmarshal(reportJSON, &reports); err != nil {
+		return nil, parseError(err, reportJSON)
+	}
+
+	var report Report
+	for _, r := range reports.Report {
+		report.VGs = append(report.VGs, r.VGs...)
+		report.PVs = append(report.PVs, r.PVs...)
+		report.LVs = append(report.LVs, r.LVs...)
+		report.PVSegments = append(report.PVSegments, r.PVSegments...)
+		report.Segments = append(report.Segments, r.Segments...)
+	}
+
+	setPoolMetadataSpares(report.VGs, report.LVs)
+
+	return &report, nil
+}
+
+// Display the effective LVM configuration as a tree of nested maps.
+func (c *Client) GetConfig(ctx context.Context, opts GetConfigOptions) (map[string]any, error) {
+	if opts.Type == "" {
+		opts.Type = "full"
+	}
+
+	// lvmconfig prints the requested keys without their parent sections, so
+	// query them one at a time so that they can be placed in the tree.
+	keys := opts.Keys
+	if len(keys) == 0 {
+		keys = []string{""}
+	}
+
+	config := make(map[string]any)
+	for _, key := range keys {
+		keyOpts := opts
+		keyOpts.Keys = nil
+		if key != "" {
+			keyOpts.Keys = []string{key}
+		}
+
+		out, err := c.run(ctx, keyOpts.commandLine()...)
+		if err != nil {
+			return nil, err
+		}
+
+		tree, err := parseConfig(out)
+		if err != nil {
+			return nil, parseError(err, out)
+		}
+
+		section := config
+		if parent := path.Dir(key); parent != "." {
+			for _, name := range strings.Split(parent, "/") {
+				section = configSection(section, name)
+			}
+		}
+
+		mergeConfig(section, tree)
+	}
+
+	return config, nil
+}
+
+// Configure the thin pools in a volume group to be automatically extended by
+// percent of their size when they are threshold percent full. The threshold
+// must be between 50 and 100, where 100 disables autoextension, and percent
+// must be positive.
+//
+// dmeventd reads these settings when it monitors a pool, so they can't be
+// passed to a single command with --config. Instead they are written to a
+// metadata profile in lvm's profile directory on this host, eg.
+// /etc/lvm/profile/autoextend-vg0.profile, which is attached to the volume
+// group. ClearThinPoolAutoextend detaches and removes the profile.
+func (c *Client) SetThinPoolAutoextend(ctx context.Context, vgName string, threshold, percent int) error {
+	if threshold < 50 || threshold > 100 {
+		return fmt.Errorf("thin pool autoextend threshold must be between 50 and 100: %d", threshold)
+	}
+
+	if percent <= 0 {
+		return fmt.Errorf("thin pool autoextend percent must be positive: %d", percent)
+	}
+
+	profilePath, err := c.thinPoolAutoextendProfilePath(ctx, vgName)
+	if err != nil {
+		return err
+	}
+
+	_, statErr := os.Stat(profilePath)
+	created := errors.Is(statErr, os.ErrNotExist)
+
+	profile := fmt.Sprintf("activation {\n\tthin_pool_autoextend_threshold = %d\n\tthin_pool_autoextend_percent = %d\n}\n", threshold, percent)
+
+	if err := os.MkdirAll(path.Dir(profilePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	if err := os.WriteFile(profilePath, []byte(profile), 0o644); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	if err := c.UpdateVolumeGroup(ctx, UpdateVGOptions{
+		Name:            vgName,
+		MetadataProfile: ThinPoolAutoextendProfile(vgName),
+	}); err != nil {
+		// Don't leave behind a profile that was never attached.
+		if created {
+			_ = os.Remove(profilePath)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Detach and remove the metadata profile written by SetThinPoolAutoextend, so
+// that the thin pools in a volume group use the autoextend settings in lvm.conf.
+func (c *Client) ClearThinPoolAutoextend(ctx context.Context, vgName string) error {
+	profilePath, err := c.thinPoolAutoextendProfilePath(ctx, vgName)
+	if err != nil {
+		return err
+	}
+
+	if err := c.UpdateVolumeGroup(ctx, UpdateVGOptions{
+		Name:          vgName,
+		DetachProfile: true,
+	}); err != nil {
+		return err
+	}
+
+	if err := os.Remove(profilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove profile: %w", err)
+	}
+
+	return nil
+}
+
+// thinPoolAutoextendProfilePath returns the path of the metadata profile
+// written by SetThinPoolAutoextend, in lvm's configured profile directory.
+func (c *Client) thinPoolAutoextendProfilePath(ctx context.Context, vgName string) (string, error) {
+	config, err := c.GetConfig(ctx, GetConfigOptions{
+		Keys: []string{"config/profile_dir"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	profileDir := "/etc/lvm/profile"
+	if section, ok := config["config"].(map[string]any); ok {
+		if dir, ok := section["profile_dir"].(string); ok && dir != "" {
+			profileDir = dir
+		}
+	}
+
+	return path.Join(profileDir, ThinPoolAutoextendProfile(vgName)+".profile"), nil
+}
+
+// ThinPoolAutoextendProfile returns the name of the metadata profile written
+// by SetThinPoolAutoextend for a volume group.
+func ThinPoolAutoextendProfile(vgName string) string {
+	return "autoextend-" + vgName
+}
+
+// Display the version of the lvm tools and device-mapper components.
+func (c *Client) Version(ctx context.Context) (Version, error) {
+	out, err := c.run(ctx, "version")
+	if err != nil {
+		return Version{}, err
+	}
+
+	return ParseVersion(out)
+}
+
+// Check that the lvm executable exists, can be run, and responds. This is
+// useful for diagnosing problems on startup, rather than on the first call.
+func (c *Client) Available(ctx context.Context) error {
+	if _, err := c.run(ctx, "version"); err != nil {
+		switch {
+		case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+			return fmt.Errorf("%w: executable %q not found: %w", ErrUnavailable, c.lvmPath, err)
+		case errors.Is(err, fs.ErrPermission):
+			return fmt.Errorf("%w: not permitted to run %q: %w", ErrUnavailable, c.lvmPath, err)
+		default:
+			return fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) run(ctx context.Context, cmdArgs ...string) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, c.lvmPath, cmdArgs...)
+	backoff := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		out, err := c.runOnce(ctx, cmdArgs...)
+		if err == nil || attempt >= c.retryAttempts || !errorContains(err, lockContentionMessages...) {
+			return out, err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return nil, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+
+		backoff *= 2
+	}
+}
+
+func (c *Client) runOnce(ctx context.Context, cmdArgs ...string) ([]byte, error) {
+	stdout, err := c.runStream(ctx, cmdArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := io.ReadAll(stdout)
+	if closeErr := stdout.Close(); closeErr != nil {
+		return nil, closeErr
+	}
+
+	return out, err
+}
+
+// acceptsDevicesFile returns true if the command reads devices and the caller
+// hasn't already chosen a devices file.
+func acceptsDevicesFile(cmdArgs []string) bool {
+	switch cmdArgs[0] {
+	case "version", "lvmconfig":
+		return false
+	}
+
+	for _, arg := range cmdArgs[1:] {
+		if strings.HasPrefix(arg, "--devicesfile") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// acceptsNoLocking returns true if the command takes locks and the caller
+// hasn't already disabled locking.
+func acceptsNoLocking(cmdArgs []string) bool {
+	switch cmdArgs[0] {
+	case "version", "lvmconfig":
+		return false
+	}
 
-	var out bytes.Buffer
-	var errOut bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &errOut
+	return !hasFlag(cmdArgs, "--nolocking")
+}
+
+// withReportDefaults adds the client's default flags to report commands, unless
+// the caller has already set them.
+func (c *Client) withReportDefaults(cmdArgs []string) []string {
+	switch cmdArgs[0] {
+	case "pvs", "vgs", "lvs", "fullreport":
+	default:
+		return cmdArgs
+	}
+
+	flags := []string{cmdArgs[0]}
+	if c.foreign && !hasFlag(cmdArgs, "--foreign") {
+		flags = append(flags, "--foreign")
+	}
+	if c.shared && !hasFlag(cmdArgs, "--shared") {
+		flags = append(flags, "--shared")
+	}
+
+	return append(flags, cmdArgs[1:]...)
+}
 
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("%w: %s", err, errOut.String())
+func hasFlag(cmdArgs []string, flag string) bool {
+	for _, arg := range cmdArgs[1:] {
+		if arg == flag || strings.HasPrefix(arg, flag+"=") {
+			return true
+		}
 	}
 
-	return out.Bytes(), nil
+	return false
 }
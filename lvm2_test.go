@@ -20,12 +20,17 @@ package lvm2_test
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"io/fs"
 	"math/big"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dpeckett/lvm2"
 	"github.com/stretchr/testify/require"
@@ -37,6 +42,21 @@ func TestClient(t *testing.T) {
 
 	c := lvm2.NewClient()
 
+	t.Run("Configuration", func(t *testing.T) {
+		ctx := context.Background()
+
+		t.Log("Getting configuration")
+
+		config, err := c.GetConfig(ctx, lvm2.GetConfigOptions{
+			Keys: []string{"global/units"},
+		})
+		require.NoError(t, err, "failed to get config")
+
+		global, ok := config["global"].(map[string]any)
+		require.True(t, ok, "expected global section")
+		require.NotEmpty(t, global["units"])
+	})
+
 	t.Run("Physical volumes", func(t *testing.T) {
 		t.Log("Creating virtual block device")
 
@@ -56,6 +76,20 @@ func TestClient(t *testing.T) {
 
 		ctx := context.Background()
 
+		t.Log("Listing devices")
+
+		devices, err := c.ListDevices(ctx)
+		require.NoError(t, err, "failed to list devices")
+
+		var found bool
+		for _, dev := range devices {
+			if dev.Path == devPath {
+				require.False(t, dev.IsPV)
+				found = true
+			}
+		}
+		require.True(t, found, "expected device to be discovered")
+
 		t.Log("Creating physical volume")
 
 		err = c.CreatePhysicalVolume(ctx, lvm2.CreatePVOptions{
@@ -71,14 +105,53 @@ func TestClient(t *testing.T) {
 		require.Len(t, pvs, 1)
 		require.Equal(t, devPath, pvs[0].Name)
 
+		pvs, err = c.ListPhysicalVolumes(ctx, &lvm2.ListPVOptions{
+			CommonOptions: lvm2.CommonOptions{
+				Devices: []string{devPath},
+			},
+		})
+		require.NoError(t, err, "failed to list PVs on devices")
+
+		require.Len(t, pvs, 1)
+		require.Equal(t, devPath, pvs[0].Name)
+
+		pv, err := c.GetPhysicalVolume(ctx, devPath)
+		require.NoError(t, err, "failed to get PV")
+		require.Equal(t, devPath, pv.Name)
+
+		t.Log("Scanning physical volumes")
+
+		err = c.ScanPhysicalVolumes(ctx, lvm2.ScanPVOptions{})
+		require.NoError(t, err, "failed to scan PVs")
+
+		err = c.ScanPhysicalVolumes(ctx, lvm2.ScanPVOptions{
+			Device: devPath,
+			Cache:  true,
+		})
+		require.NoError(t, err, "failed to scan PVs with cache")
+
 		t.Log("Changing physical volume UUID")
 
+		pv, err = c.GetPhysicalVolume(ctx, devPath)
+		require.NoError(t, err, "failed to get PV")
+		require.NotEmpty(t, pv.UUID)
+
+		// Network block devices are assigned major number 43.
+		require.EqualValues(t, 43, pv.Major)
+
+		oldUUID := pv.UUID
+
 		err = c.UpdatePhysicalVolume(ctx, lvm2.UpdatePVOptions{
 			Name: devPath,
 			UUID: true,
 		})
 		require.NoError(t, err, "failed to change PV")
 
+		pv, err = c.GetPhysicalVolume(ctx, devPath)
+		require.NoError(t, err, "failed to get PV")
+		require.NotEmpty(t, pv.UUID)
+		require.NotEqual(t, oldUUID, pv.UUID)
+
 		t.Log("Resizing physical volume")
 
 		err = c.ResizePhysicalVolume(ctx, lvm2.ResizePVOptions{
@@ -103,6 +176,13 @@ func TestClient(t *testing.T) {
 		})
 		require.NoError(t, err, "failed to check PV")
 
+		out, err := c.CheckPhysicalVolumeWithOutput(ctx, lvm2.CheckPVOptions{
+			Name: devPath,
+			Dump: "headers",
+		})
+		require.NoError(t, err, "failed to dump PV headers")
+		require.Contains(t, out, "label_header")
+
 		t.Log("Removing physical volume")
 
 		err = c.RemovePhysicalVolume(ctx, lvm2.RemovePVOptions{
@@ -115,6 +195,108 @@ func TestClient(t *testing.T) {
 		})
 		require.Contains(t, err.Error(), "Failed to find physical volume")
 		require.Empty(t, pvs)
+
+		_, err = c.GetPhysicalVolume(ctx, devPath)
+		require.ErrorIs(t, err, lvm2.ErrPhysicalVolumeNotFound)
+
+		t.Log("Creating physical volume without metadata")
+
+		err = c.CreatePhysicalVolume(ctx, lvm2.CreatePVOptions{
+			Name:           devPath,
+			MetadataCopies: lvm2.PtrTo(0),
+		})
+		require.NoError(t, err, "failed to create PV")
+
+		pv, err = c.GetPhysicalVolume(ctx, devPath)
+		require.NoError(t, err, "failed to get PV")
+		require.Zero(t, pv.MetadataCount)
+
+		err = c.RemovePhysicalVolume(ctx, lvm2.RemovePVOptions{
+			Name: devPath,
+		})
+		require.NoError(t, err, "failed to remove PV")
+
+		t.Log("Creating physical volume with data alignment")
+
+		err = c.CreatePhysicalVolume(ctx, lvm2.CreatePVOptions{
+			Name:          devPath,
+			DataAlignment: "1m",
+		})
+		require.NoError(t, err, "failed to create PV")
+
+		pv, err = c.GetPhysicalVolume(ctx, devPath)
+		require.NoError(t, err, "failed to get PV")
+		require.Equal(t, "1.00m", pv.ExtentStart)
+
+		err = c.RemovePhysicalVolume(ctx, lvm2.RemovePVOptions{
+			Name: devPath,
+		})
+		require.NoError(t, err, "failed to remove PV")
+	})
+
+	t.Run("Devices file", func(t *testing.T) {
+		imagePath := filepath.Join(t.TempDir(), ".qcow2")
+		err := createImage(imagePath)
+		require.NoError(t, err)
+
+		devPath, err := attachNBDDevice(imagePath)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			err := detachNBDDevice(devPath)
+			require.NoError(t, err)
+		})
+
+		ctx := context.Background()
+
+		devicesFile := uniqueName("test") + ".devices"
+		devicesFilePath := filepath.Join("/etc/lvm/devices", devicesFile)
+
+		t.Cleanup(func() {
+			_ = os.Remove(devicesFilePath)
+		})
+
+		commonOpts := lvm2.CommonOptions{
+			DevicesFile: devicesFile,
+		}
+
+		t.Log("Adding device to devices file", devicesFile)
+
+		err = c.AddDevice(ctx, lvm2.AddDeviceOptions{
+			CommonOptions: commonOpts,
+			Name:          devPath,
+			DeviceIDType:  "devname",
+		})
+		require.NoError(t, err, "failed to add device")
+
+		contents, err := os.ReadFile(devicesFilePath)
+		require.NoError(t, err)
+		require.Contains(t, string(contents), "IDNAME="+devPath)
+
+		devices, err := c.ListConfiguredDevices(ctx, devicesFile)
+		require.NoError(t, err, "failed to list configured devices")
+		require.Len(t, devices, 1)
+		require.Equal(t, "devname", devices[0].IDType)
+		require.Equal(t, devPath, devices[0].IDName)
+		require.Equal(t, devPath, devices[0].DevName)
+		require.Empty(t, devices[0].PVID)
+
+		err = c.CheckDevices(ctx, lvm2.CheckDevicesOptions{
+			CommonOptions: commonOpts,
+		})
+		require.NoError(t, err, "failed to check devices")
+
+		t.Log("Removing device from devices file")
+
+		err = c.RemoveDevice(ctx, lvm2.RemoveDeviceOptions{
+			CommonOptions: commonOpts,
+			Name:          devPath,
+		})
+		require.NoError(t, err, "failed to remove device")
+
+		contents, err = os.ReadFile(devicesFilePath)
+		require.NoError(t, err)
+		require.NotContains(t, string(contents), "IDNAME="+devPath)
 	})
 
 	t.Run("Volume groups", func(t *testing.T) {
@@ -173,7 +355,7 @@ func TestClient(t *testing.T) {
 		t.Cleanup(func() {
 			_ = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
 				Name:     vgName,
-				Activate: lvm2.No,
+				Activate: lvm2.ActivateNo,
 			})
 
 			_ = c.RemoveVolumeGroup(ctx, lvm2.RemoveVGOptions{
@@ -190,14 +372,78 @@ func TestClient(t *testing.T) {
 		require.Equal(t, vgName, vgs[0].Name)
 		require.Equal(t, 1, int(vgs[0].PVCount))
 
+		t.Log("Checking physical volume free space")
+
+		pv, err := c.GetPhysicalVolume(ctx, firstDevPath)
+		require.NoError(t, err, "failed to get PV")
+		require.Equal(t, vgName, pv.VGName)
+		require.NotEqual(t, "0", pv.FreeSpace)
+		require.Positive(t, int(pv.ExtentCount))
+		require.Zero(t, pv.ExtentAllocCount)
+		require.Equal(t, "4.00m", pv.ExtentSize)
+
+		t.Log("Getting volume group")
+
+		vg, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Equal(t, vgName, vg.Name)
+
+		_, err = c.GetVolumeGroup(ctx, uniqueName("missing"))
+		require.ErrorIs(t, err, lvm2.ErrVolumeGroupNotFound)
+
 		t.Log("Activating volume group")
 
 		err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
 			Name:     vgName,
-			Activate: lvm2.Yes,
+			Activate: lvm2.ActivateYes,
 		})
 		require.NoError(t, err, "failed to activate VG")
 
+		t.Log("Toggling volume group monitoring")
+
+		for _, monitor := range []*lvm2.YesNo{lvm2.Yes, lvm2.No} {
+			err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+				Name:    vgName,
+				Monitor: monitor,
+			})
+			// Monitoring isn't available when dmeventd isn't running.
+			if err != nil && strings.Contains(err.Error(), "dmeventd") {
+				t.Log("Monitoring unavailable:", err)
+				continue
+			}
+			require.NoError(t, err, "failed to toggle VG monitoring")
+		}
+
+		t.Log("Configuring thin pool autoextend")
+
+		err = c.SetThinPoolAutoextend(ctx, vgName, 80, 20)
+		require.NoError(t, err, "failed to configure thin pool autoextend")
+
+		vg, err = c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Equal(t, lvm2.ThinPoolAutoextendProfile(vgName), vg.Profile)
+
+		config, err := c.GetConfig(ctx, lvm2.GetConfigOptions{
+			Keys:            []string{"activation/thin_pool_autoextend_threshold", "activation/thin_pool_autoextend_percent"},
+			MetadataProfile: vg.Profile,
+		})
+		require.NoError(t, err, "failed to get config")
+		require.Equal(t, map[string]any{
+			"activation": map[string]any{
+				"thin_pool_autoextend_threshold": int64(80),
+				"thin_pool_autoextend_percent":   int64(20),
+			},
+		}, config)
+
+		t.Log("Clearing thin pool autoextend")
+
+		err = c.ClearThinPoolAutoextend(ctx, vgName)
+		require.NoError(t, err, "failed to clear thin pool autoextend")
+
+		vg, err = c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Empty(t, vg.Profile)
+
 		t.Log("Adding second physical volume to volume group")
 
 		err = c.ExtendVolumeGroup(ctx, lvm2.ExtendVGOptions{
@@ -250,7 +496,7 @@ func TestClient(t *testing.T) {
 		require.NoError(t, err, "failed to add tag to VG")
 
 		vgs, err = c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
-			Select: "vg_tags=" + vgTag,
+			Select: lvm2.Select().Eq("vg_tags", vgTag).String(),
 		})
 		require.NoError(t, err, "failed to list VGs")
 
@@ -267,7 +513,7 @@ func TestClient(t *testing.T) {
 		require.NoError(t, err, "failed to merge VGs")
 
 		vgs, err = c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
-			Select: "vg_tags=" + vgTag,
+			Select: lvm2.Select().Eq("vg_tags", vgTag).String(),
 		})
 		require.NoError(t, err, "failed to list VGs")
 
@@ -284,12 +530,33 @@ func TestClient(t *testing.T) {
 		require.NoError(t, err, "failed to remove VG")
 
 		vgs, err = c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
-			Select: "vg_tags=" + vgTag,
+			Select: lvm2.Select().Eq("vg_tags", vgTag).String(),
 		})
 		require.NoError(t, err, "failed to list VGs")
 
 		require.Len(t, vgs, 1)
 
+		t.Log("Exporting and importing volume group")
+
+		err = c.ExportVolumeGroup(ctx, lvm2.ExportVGOptions{
+			Name: vgName,
+		})
+		require.NoError(t, err, "failed to export VG")
+
+		vg, err = c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.True(t, bool(vg.Exported))
+		require.True(t, vg.Attr().Exported)
+
+		err = c.ImportVolumeGroup(ctx, lvm2.ImportVGOptions{
+			Name: vgName,
+		})
+		require.NoError(t, err, "failed to import VG")
+
+		vg, err = c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.False(t, bool(vg.Exported))
+
 		t.Log("Checking volume group")
 
 		err = c.CheckVolumeGroup(ctx, lvm2.CheckVGOptions{
@@ -298,6 +565,15 @@ func TestClient(t *testing.T) {
 		})
 		require.NoError(t, err, "failed to check VG")
 
+		t.Log("Resuming interrupted operations")
+
+		// There are no operations in progress, so this is a no-op.
+		err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			Name: vgName,
+			Poll: lvm2.Yes,
+		})
+		require.NoError(t, err, "failed to poll VG")
+
 		t.Log("Removing volume group")
 
 		err = c.RemoveVolumeGroup(ctx, lvm2.RemoveVGOptions{
@@ -312,6 +588,109 @@ func TestClient(t *testing.T) {
 		require.Empty(t, vgs)
 	})
 
+	t.Run("Volume group creation options", func(t *testing.T) {
+		t.Log("Creating volume group with a 16M extent size")
+
+		vgName, _ := createVolumeGroupWithOptions(t, c, 1, lvm2.CreateVGOptions{
+			PhysicalExtentSize: "16M",
+			MaxPhysicalVolumes: lvm2.PtrTo(4),
+			MaxLogicalVolumes:  lvm2.PtrTo(8),
+		})
+
+		vg, err := c.GetVolumeGroup(context.Background(), vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Equal(t, "16.00m", vg.ExtentSize)
+
+		t.Log("Creating volume group with 4M data alignment")
+
+		vgName, devPaths := createVolumeGroupWithOptions(t, c, 1, lvm2.CreateVGOptions{
+			DataAlignment: "4m",
+		})
+
+		pv, err := c.GetPhysicalVolume(context.Background(), devPaths[0])
+		require.NoError(t, err, "failed to get PV")
+		require.Equal(t, vgName, pv.VGName)
+		require.Equal(t, "4.00m", pv.ExtentStart)
+	})
+
+	t.Run("Volume group system ID", func(t *testing.T) {
+		ctx := context.Background()
+
+		vgName, _ := createVolumeGroup(t, c, 1)
+
+		// Give this host a system ID, so that it owns the VG after the change.
+		systemIDConfig := lvm2.CommonOptions{
+			Config: `global { system_id_source = "lvmlocal" } local { system_id = "lvm2-test" }`,
+		}
+
+		t.Log("Setting volume group system ID")
+
+		err := c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			CommonOptions: systemIDConfig,
+			Name:          vgName,
+			SystemID:      lvm2.PtrTo("lvm2-test"),
+		})
+		require.NoError(t, err, "failed to set VG system ID")
+
+		vgs, err := c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
+			CommonOptions: systemIDConfig,
+			Names:         []string{vgName},
+		})
+		require.NoError(t, err, "failed to list VGs")
+
+		require.Len(t, vgs, 1)
+		require.Equal(t, "lvm2-test", vgs[0].SystemID)
+
+		t.Log("Clearing volume group system ID")
+
+		err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			CommonOptions: systemIDConfig,
+			Name:          vgName,
+			SystemID:      lvm2.PtrTo(""),
+		})
+		require.NoError(t, err, "failed to clear VG system ID")
+
+		vg, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Empty(t, vg.SystemID)
+	})
+
+	t.Run("Shared volume groups", func(t *testing.T) {
+		ctx := context.Background()
+
+		if _, err := os.Stat("/run/lvm/lvmlockd.socket"); err != nil {
+			t.Skip("lvmlockd is not running")
+		}
+
+		config, err := c.GetConfig(ctx, lvm2.GetConfigOptions{
+			Keys: []string{"global/use_lvmlockd"},
+		})
+		require.NoError(t, err)
+
+		if global, _ := config["global"].(map[string]any); global["use_lvmlockd"] != int64(1) {
+			t.Skip("lvm is not configured to use lvmlockd")
+		}
+
+		t.Log("Creating shared volume group")
+
+		vgName, _ := createVolumeGroupWithOptions(t, c, 1, lvm2.CreateVGOptions{
+			Shared: true,
+		})
+
+		t.Log("Starting volume group lockspace")
+
+		err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			Name:      vgName,
+			LockStart: true,
+		})
+		require.NoError(t, err, "failed to start VG lockspace")
+
+		vg, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.True(t, bool(vg.Shared))
+		require.NotEmpty(t, vg.LockType)
+	})
+
 	t.Run("Logical volumes", func(t *testing.T) {
 		t.Log("Creating virtual block device")
 
@@ -344,22 +723,81 @@ func TestClient(t *testing.T) {
 		t.Cleanup(func() {
 			err := c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
 				Name:     vgName,
-				Activate: lvm2.No,
+				Activate: lvm2.ActivateNo,
 			})
 			require.NoError(t, err)
 		})
 
+		vgBefore, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Zero(t, vgBefore.LVCount)
+
+		availableBefore, err := c.AvailableBytes(ctx, vgName)
+		require.NoError(t, err, "failed to get available space")
+
 		lvName := uniqueName(strings.ReplaceAll(t.Name(), "/", "_"))
 
 		t.Log("Creating logical volume", lvName)
 
-		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+		out, err := c.CreateLogicalVolumeWithOutput(ctx, lvm2.CreateLVOptions{
 			Name:     lvName,
 			VGName:   vgName,
 			Size:     "100M",
-			Activate: lvm2.No,
+			Activate: lvm2.ActivateNo,
 		})
 		require.NoError(t, err, "failed to create LV")
+		require.Contains(t, out, fmt.Sprintf("Logical volume %q created.", lvName))
+
+		t.Log("Checking volume group free space")
+
+		vgAfter, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.EqualValues(t, 1, vgAfter.LVCount)
+		require.Equal(t, vgBefore.ExtentCount, vgAfter.ExtentCount)
+		require.Equal(t, vgBefore.ExtentFreeCount-25, vgAfter.ExtentFreeCount)
+		require.NotEqual(t, vgBefore.Free, vgAfter.Free)
+		require.Equal(t, "4.00m", vgAfter.ExtentSize)
+
+		count, extentSize, err := c.AvailableExtents(ctx, vgName)
+		require.NoError(t, err, "failed to get available extents")
+		require.EqualValues(t, vgAfter.ExtentFreeCount, count)
+		require.Equal(t, 4*lvm2.Mebibyte, extentSize)
+
+		availableAfter, err := c.AvailableBytes(ctx, vgName)
+		require.NoError(t, err, "failed to get available space")
+		require.Equal(t, availableBefore-100*lvm2.Mebibyte, availableAfter)
+
+		t.Log("Getting full report")
+
+		report, err := c.FullReport(ctx, &lvm2.FullReportOptions{
+			VGNames: []string{vgName},
+		})
+		require.NoError(t, err, "failed to get full report")
+
+		require.Len(t, report.VGs, 1)
+		require.Equal(t, vgName, report.VGs[0].Name)
+		require.Len(t, report.PVs, 1)
+		require.Equal(t, devPath, report.PVs[0].Name)
+		require.Len(t, report.LVs, 1)
+		require.Equal(t, lvName, report.LVs[0].Name)
+		require.Len(t, report.Segments, 1)
+		require.Equal(t, lvm2.LVTypeLinear, report.Segments[0].Type)
+		require.NotEmpty(t, report.PVSegments)
+
+		t.Log("Listing physical volume segments")
+
+		segments, err := c.ListPhysicalVolumeSegments(ctx, devPath)
+		require.NoError(t, err, "failed to list PV segments")
+		require.Len(t, segments, 2)
+
+		require.EqualValues(t, 0, segments[0].Start)
+		require.EqualValues(t, 25, segments[0].Length)
+		require.Equal(t, lvName, segments[0].LVName)
+		require.False(t, segments[0].Free())
+
+		require.EqualValues(t, 25, segments[1].Start)
+		require.EqualValues(t, vgAfter.ExtentFreeCount, segments[1].Length)
+		require.True(t, segments[1].Free())
 
 		lvs, err := c.ListLogicalVolumes(ctx, &lvm2.ListLVOptions{
 			Names: []string{
@@ -371,7 +809,19 @@ func TestClient(t *testing.T) {
 		require.Len(t, lvs, 1)
 		require.Equal(t, lvName, lvs[0].Name)
 		require.Equal(t, "100.00m", lvs[0].Size)
-		require.Empty(t, lvs[0].Active)
+		require.False(t, lvs[0].IsActive())
+		require.False(t, lvs[0].CopyPercent.Valid)
+
+		t.Log("Getting logical volume")
+
+		lv, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+
+		require.Equal(t, lvName, lv.Name)
+		require.Equal(t, vgName, lv.VGName)
+
+		_, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, uniqueName("missing")))
+		require.ErrorIs(t, err, lvm2.ErrLogicalVolumeNotFound)
 
 		t.Log("Resizing logical volume")
 
@@ -407,6 +857,24 @@ func TestClient(t *testing.T) {
 		require.Len(t, lvs, 1)
 		require.Equal(t, "128.00m", lvs[0].Size)
 
+		t.Log("Extending logical volume into all free space")
+
+		err = c.ExtendLogicalVolume(ctx, lvm2.ExtendLVOptions{
+			Name:    fmt.Sprintf("%s/%s", vgName, lvName),
+			Extents: "+100%FREE",
+		})
+		require.NoError(t, err, "failed to extend LV")
+
+		vg, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Zero(t, vg.ExtentFreeCount)
+
+		err = c.ReduceLogicalVolume(ctx, lvm2.ReduceLVOptions{
+			Name: fmt.Sprintf("%s/%s", vgName, lvName),
+			Size: "128M",
+		})
+		require.NoError(t, err, "failed to reduce LV")
+
 		t.Log("Renaming and activating logical volume")
 
 		err = c.RenameLogicalVolume(ctx, lvm2.RenameLVOptions{
@@ -419,7 +887,7 @@ func TestClient(t *testing.T) {
 
 		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
 			Name:     fmt.Sprintf("%s/%s", vgName, lvName),
-			Activate: lvm2.Yes,
+			Activate: lvm2.ActivateYes,
 		})
 		require.NoError(t, err, "failed to activate LV")
 
@@ -432,7 +900,7 @@ func TestClient(t *testing.T) {
 
 		require.Len(t, lvs, 1)
 		require.Equal(t, lvName, lvs[0].Name)
-		require.NotEmpty(t, lvs[0].Active)
+		require.True(t, lvs[0].IsActive())
 
 		t.Log("Creating second virtual block device")
 
@@ -467,7 +935,7 @@ func TestClient(t *testing.T) {
 
 		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
 			Name:    fmt.Sprintf("%s/%s", vgName, lvName),
-			Type:    "raid1",
+			Type:    lvm2.LVTypeRAID1,
 			Mirrors: lvm2.PtrTo(1),
 		})
 		require.NoError(t, err, "failed to convert LV to RAID1")
@@ -480,7 +948,66 @@ func TestClient(t *testing.T) {
 		require.NoError(t, err, "failed to list LVs")
 
 		require.Len(t, lvs, 1)
-		require.Equal(t, "raid1", lvs[0].Type, "expected LV to be of type RAID1")
+		require.Equal(t, lvm2.LVTypeRAID1, lvs[0].Type, "expected LV to be of type RAID1")
+
+		t.Log("Waiting for RAID1 logical volume to synchronize")
+
+		require.Eventually(t, func() bool {
+			lvs, err := c.ListLogicalVolumes(ctx, &lvm2.ListLVOptions{
+				Names: []string{
+					fmt.Sprintf("%s/%s", vgName, lvName),
+				},
+			})
+			return err == nil && len(lvs) == 1 && lvs[0].CopyPercent.Valid && lvs[0].CopyPercent.Float64 == 100
+		}, time.Minute, time.Second)
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, lvm2.LVHealthOK, lv.Health())
+
+		t.Log("Scrubbing logical volume")
+
+		err = c.ScrubLogicalVolume(ctx, lvm2.ScrubLVOptions{
+			Name:   fmt.Sprintf("%s/%s", vgName, lvName),
+			Action: lvm2.ScrubCheck,
+		})
+		require.NoError(t, err, "failed to scrub LV")
+
+		lvs, err = c.ListLogicalVolumes(ctx, &lvm2.ListLVOptions{
+			Names: []string{
+				fmt.Sprintf("%s/%s", vgName, lvName),
+			},
+		})
+		require.NoError(t, err, "failed to list LVs")
+
+		require.Len(t, lvs, 1)
+		require.Contains(t, []string{"check", "idle"}, lvs[0].RAIDSyncAction)
+		require.Zero(t, lvs[0].RAIDMismatchCount)
+
+		t.Log("Splitting a mirror leg off the RAID1 logical volume")
+
+		splitName := uniqueName("split")
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:         fmt.Sprintf("%s/%s", vgName, lvName),
+			SplitMirrors: lvm2.PtrTo(1),
+			NewName:      splitName,
+		})
+		require.NoError(t, err, "failed to split mirror leg")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, lvm2.LVTypeLinear, lv.Type)
+
+		split, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, splitName))
+		require.NoError(t, err, "failed to get split LV")
+		require.Equal(t, lvm2.LVTypeLinear, split.Type)
+		require.Equal(t, lv.Size, split.Size)
+
+		err = c.RemoveLogicalVolume(ctx, lvm2.RemoveLVOptions{
+			Name: fmt.Sprintf("%s/%s", vgName, splitName),
+		})
+		require.NoError(t, err, "failed to remove split LV")
 
 		t.Log("Removing second physical volume from volume group")
 
@@ -489,34 +1016,1983 @@ func TestClient(t *testing.T) {
 		})
 		require.NoError(t, err, "failed to remove logical volume")
 	})
-}
 
-func loadNBDModule() error {
-	cmd := exec.Command("/sbin/modprobe", "nbd", "max_part=16")
-	return cmd.Run()
-}
+	t.Run("Deferred activation", func(t *testing.T) {
+		vgName, _ := createVolumeGroup(t, c, 1)
 
-func createImage(imagePath string) error {
-	cmd := exec.Command("qemu-img", "create", "-f", "qcow2", imagePath, "1G")
-	return cmd.Run()
-}
+		ctx := context.Background()
 
-func attachNBDDevice(imagePath string) (string, error) {
-	for i := 0; i < 16; i++ {
-		devPath := fmt.Sprintf("/dev/nbd%d", i)
-		cmd := exec.Command("qemu-nbd", "-c", devPath, imagePath)
-		err := cmd.Run()
-		if err == nil {
-			return devPath, nil
-		}
-	}
+		lvName := uniqueName("deferred")
+		fullName := fmt.Sprintf("%s/%s", vgName, lvName)
+		devPath := fmt.Sprintf("/dev/%s/%s", vgName, lvName)
 
-	return "", fmt.Errorf("no free nbd device found")
-}
+		t.Log("Creating inactive logical volume", lvName)
 
-func detachNBDDevice(devPath string) error {
-	cmd := exec.Command("qemu-nbd", "-d", devPath)
-	return cmd.Run()
+		err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:              lvName,
+			VGName:            vgName,
+			Size:              "16M",
+			Activate:          lvm2.ActivateNo,
+			SetActivationSkip: lvm2.Yes,
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		lv, err := c.GetLogicalVolume(ctx, fullName)
+		require.NoError(t, err, "failed to get LV")
+		require.False(t, lv.IsActive())
+		require.True(t, bool(lv.SkipActivation))
+
+		_, err = os.Stat(devPath)
+		require.ErrorIs(t, err, fs.ErrNotExist, "expected no device node before activation")
+
+		t.Log("Activating volume group skips the logical volume")
+
+		err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			Name:           vgName,
+			Activate:       lvm2.ActivateYes,
+			ActivationMode: lvm2.ActivationModeComplete,
+		})
+		require.NoError(t, err, "failed to activate VG")
+
+		lv, err = c.GetLogicalVolume(ctx, fullName)
+		require.NoError(t, err, "failed to get LV")
+		require.False(t, lv.IsActive())
+
+		t.Log("Activating logical volume exclusively")
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:                 fullName,
+			Activate:             lvm2.ActivateExclusive,
+			IgnoreActivationSkip: true,
+		})
+		require.NoError(t, err, "failed to activate LV")
+
+		path, err := c.WaitForDeviceNode(ctx, vgName, lvName)
+		require.NoError(t, err, "failed to wait for device node")
+		require.Equal(t, devPath, path)
+
+		lv, err = c.GetLogicalVolume(ctx, fullName)
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, lv.IsActive())
+	})
+
+	t.Run("Logical volume options", func(t *testing.T) {
+		vgName, devPaths := createVolumeGroup(t, c, 2)
+
+		ctx := context.Background()
+
+		t.Log("Creating logical volume without zeroing or wiping signatures")
+
+		lvName := uniqueName("unzeroed")
+
+		err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:           lvName,
+			VGName:         vgName,
+			Size:           "16M",
+			Zero:           lvm2.No,
+			WipeSignatures: lvm2.No,
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		t.Log("Resolving the device-mapper path of an active logical volume")
+
+		lv, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.NotEmpty(t, lv.UUID)
+		require.Equal(t, "/dev/mapper/"+lvm2.DMName(vgName, lvName), lv.DMPath)
+
+		_, err = os.Stat(lv.DMPath)
+		require.NoError(t, err, "expected device-mapper path to exist")
+
+		t.Log("Force removing an active logical volume")
+
+		lvName = uniqueName("active")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:     lvName,
+			VGName:   vgName,
+			Size:     "16M",
+			Activate: lvm2.ActivateYes,
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		err = c.RemoveLogicalVolume(ctx, lvm2.RemoveLVOptions{
+			Name:  fmt.Sprintf("%s/%s", vgName, lvName),
+			Force: true,
+		})
+		require.NoError(t, err, "failed to force remove LV")
+
+		_, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.ErrorIs(t, err, lvm2.ErrLogicalVolumeNotFound)
+
+		t.Log("Activating a logical volume exclusively")
+
+		lvName = uniqueName("exclusive")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:     lvName,
+			VGName:   vgName,
+			Size:     "16M",
+			Activate: lvm2.ActivateNo,
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:     fmt.Sprintf("%s/%s", vgName, lvName),
+			Activate: lvm2.ActivateExclusive,
+		})
+		require.NoError(t, err, "failed to activate LV exclusively")
+
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		t.Cleanup(cancel)
+
+		devPath, err := c.WaitForDeviceNode(waitCtx, vgName, lvName)
+		require.NoError(t, err, "failed to wait for device node")
+		require.Equal(t, fmt.Sprintf("/dev/%s/%s", vgName, lvName), devPath)
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, lv.IsActive())
+
+		t.Log("Refreshing an active logical volume")
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:    fmt.Sprintf("%s/%s", vgName, lvName),
+			Refresh: true,
+		})
+		require.NoError(t, err, "failed to refresh LV")
+
+		t.Log("Creating a logical volume that is skipped on activation")
+
+		lvName = uniqueName("skipped")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:              lvName,
+			VGName:            vgName,
+			Size:              "16M",
+			SetActivationSkip: lvm2.Yes,
+			Activate:          lvm2.ActivateNo,
+			Zero:              lvm2.No,
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, bool(lv.SkipActivation))
+		require.True(t, lv.Attr().SkipActivation)
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:              fmt.Sprintf("%s/%s", vgName, lvName),
+			SetActivationSkip: lvm2.No,
+		})
+		require.NoError(t, err, "failed to clear activation skip")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.False(t, bool(lv.SkipActivation))
+
+		t.Log("Creating a read-only logical volume")
+
+		lvName = uniqueName("readonly")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:       lvName,
+			VGName:     vgName,
+			Size:       "16M",
+			Permission: "r",
+			Zero:       lvm2.No,
+		})
+		require.NoError(t, err, "failed to create read-only LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, "read-only", lv.Permissions)
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:       fmt.Sprintf("%s/%s", vgName, lvName),
+			Permission: "rw",
+		})
+		require.NoError(t, err, "failed to make LV writeable")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, "writeable", lv.Permissions)
+
+		t.Log("Extending a logical volume together with its filesystem")
+
+		lvName = uniqueName("ext4")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:   lvName,
+			VGName: vgName,
+			Size:   "64M",
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		devPath = fmt.Sprintf("/dev/%s/%s", vgName, lvName)
+
+		err = exec.Command("mkfs.ext4", "-q", devPath).Run()
+		require.NoError(t, err, "failed to create filesystem")
+
+		sizeBefore, err := filesystemSize(devPath)
+		require.NoError(t, err)
+
+		err = c.ExtendLogicalVolume(ctx, lvm2.ExtendLVOptions{
+			Name:     fmt.Sprintf("%s/%s", vgName, lvName),
+			Size:     "+64M",
+			ResizeFS: true,
+		})
+		require.NoError(t, err, "failed to extend LV and filesystem")
+
+		sizeAfter, err := filesystemSize(devPath)
+		require.NoError(t, err)
+		require.Greater(t, sizeAfter, sizeBefore)
+
+		t.Log("Cloning a thick logical volume")
+
+		err = c.CloneLogicalVolume(ctx, lvm2.CloneLVOptions{
+			Origin: fmt.Sprintf("%s/%s", vgName, lvName),
+			Name:   lvName + "_clone",
+			Size:   "16M",
+		})
+		require.NoError(t, err, "failed to clone thick LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s_clone", vgName, lvName))
+		require.NoError(t, err, "failed to get clone")
+		require.Equal(t, lvName, lv.Origin)
+
+		percentBefore, ok := lv.SnapPercent()
+		require.True(t, ok, "expected snapshot usage")
+		require.False(t, lv.IsSnapshotFull())
+
+		t.Log("Writing to the origin of the clone")
+
+		err = exec.Command("dd", "if=/dev/urandom", "of="+devPath, "bs=1M", "seek=32", "count=4", "oflag=direct").Run()
+		require.NoError(t, err, "failed to write to origin")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s_clone", vgName, lvName))
+		require.NoError(t, err, "failed to get clone")
+
+		percentAfter, ok := lv.SnapPercent()
+		require.True(t, ok, "expected snapshot usage")
+		require.Greater(t, percentAfter, percentBefore)
+		require.False(t, lv.IsSnapshotFull())
+
+		t.Log("Creating a contiguous logical volume")
+
+		lvName = uniqueName("contiguous")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:   lvName,
+			VGName: vgName,
+			Size:   "32M",
+			Alloc:  "contiguous",
+		})
+		require.NoError(t, err, "failed to create contiguous LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, "contiguous", lv.AllocationPolicy)
+		require.Equal(t, lvm2.AllocationContiguous, lv.Attr().AllocationPolicy)
+
+		vg, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Equal(t, "normal", vg.AllocationPolicy)
+
+		t.Log("Creating a logical volume on a specific physical volume")
+
+		lvName = uniqueName("placed")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:    lvName,
+			VGName:  vgName,
+			Size:    "16M",
+			PVNames: []string{devPaths[1]},
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, strings.HasPrefix(lv.Devices, devPaths[1]+"("), "unexpected devices: %s", lv.Devices)
+
+		t.Log("Draining a physical volume before allocation")
+
+		err = c.UpdatePhysicalVolume(ctx, lvm2.UpdatePVOptions{
+			Name:        devPaths[0],
+			Allocatable: lvm2.No,
+			AutoBackup:  lvm2.Yes,
+		})
+		require.NoError(t, err, "failed to drain PV")
+
+		pv, err := c.GetPhysicalVolume(ctx, devPaths[0])
+		require.NoError(t, err, "failed to get PV")
+		require.False(t, bool(pv.Allocatable))
+
+		allocCount := pv.ExtentAllocCount
+
+		lvName = uniqueName("drained")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:   lvName,
+			VGName: vgName,
+			Size:   "16M",
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.NotContains(t, lv.Devices, devPaths[0]+"(")
+
+		pv, err = c.GetPhysicalVolume(ctx, devPaths[0])
+		require.NoError(t, err, "failed to get PV")
+		require.Equal(t, allocCount, pv.ExtentAllocCount)
+
+		err = c.UpdatePhysicalVolume(ctx, lvm2.UpdatePVOptions{
+			Name:        devPaths[0],
+			Allocatable: lvm2.Yes,
+		})
+		require.NoError(t, err, "failed to make PV allocatable")
+
+		t.Log("Tagging a physical volume")
+
+		err = c.UpdatePhysicalVolume(ctx, lvm2.UpdatePVOptions{
+			Name:    devPaths[1],
+			AddTags: []string{"ssd", "fast"},
+		})
+		require.NoError(t, err, "failed to tag PV")
+
+		err = c.UpdatePhysicalVolume(ctx, lvm2.UpdatePVOptions{
+			Name:    devPaths[1],
+			DelTags: []string{"fast"},
+		})
+		require.NoError(t, err, "failed to untag PV")
+
+		pvs, err := c.ListPhysicalVolumes(ctx, &lvm2.ListPVOptions{
+			Select: lvm2.Select().Eq("vg_name", vgName).And().Eq("pv_tags", "ssd").String(),
+		})
+		require.NoError(t, err, "failed to list PVs")
+
+		require.Len(t, pvs, 1)
+		require.Equal(t, devPaths[1], pvs[0].Name)
+		require.Equal(t, "ssd", pvs[0].Tags)
+
+		t.Log("Creating a logical volume on tagged physical volumes only")
+
+		lvName = uniqueName("tagged")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:           lvName,
+			VGName:         vgName,
+			Size:           "16M",
+			AllocationTags: []string{"ssd"},
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, strings.HasPrefix(lv.Devices, devPaths[1]+"("), "unexpected devices: %s", lv.Devices)
+
+		t.Log("Creating a logical volume with a persistent minor number")
+
+		lvName = uniqueName("persistent")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:       lvName,
+			VGName:     vgName,
+			Size:       "16M",
+			Persistent: lvm2.Yes,
+			Minor:      lvm2.PtrTo(242),
+		})
+		require.NoError(t, err, "failed to create LV with persistent minor")
+
+		lvs, err := c.ListLogicalVolumes(ctx, &lvm2.ListLVOptions{
+			Names: []string{fmt.Sprintf("%s/%s", vgName, lvName)},
+		})
+		require.NoError(t, err, "failed to list LVs")
+
+		require.Len(t, lvs, 1)
+		require.True(t, bool(lvs[0].FixedMinor))
+		require.EqualValues(t, 242, lvs[0].Minor)
+
+		t.Log("Listing only active logical volumes")
+
+		activeName := lvName
+		inactiveName := uniqueName("inactive")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:     inactiveName,
+			VGName:   vgName,
+			Size:     "16M",
+			Activate: lvm2.ActivateNo,
+		})
+		require.NoError(t, err, "failed to create inactive LV")
+
+		for active, expected := range map[bool]string{true: activeName, false: inactiveName} {
+			lvs, err = c.ListLogicalVolumes(ctx, &lvm2.ListLVOptions{
+				Names:  []string{fmt.Sprintf("%s/%s", vgName, activeName), fmt.Sprintf("%s/%s", vgName, inactiveName)},
+				Active: &active,
+			})
+			require.NoError(t, err, "failed to list LVs")
+
+			require.Len(t, lvs, 1)
+			require.Equal(t, expected, lvs[0].Name)
+		}
+
+		t.Log("Setting logical volume read-ahead")
+
+		lvName = uniqueName("readahead")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:      lvName,
+			VGName:    vgName,
+			Size:      "16M",
+			ReadAhead: "256",
+		})
+		require.NoError(t, err, "failed to create LV with read-ahead")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		// Read-ahead is reported in the current units, 256 sectors is 128KiB.
+		require.Equal(t, "128.00k", lv.ReadAhead)
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:      fmt.Sprintf("%s/%s", vgName, lvName),
+			ReadAhead: "1024",
+		})
+		require.NoError(t, err, "failed to update LV read-ahead")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, "512.00k", lv.ReadAhead)
+
+		t.Log("Creating a mirrored logical volume with a core log")
+
+		lvName = uniqueName("mirror")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:      lvName,
+			VGName:    vgName,
+			Size:      "16M",
+			Type:      lvm2.LVTypeMirror,
+			Mirrors:   lvm2.PtrTo(1),
+			MirrorLog: "core",
+		})
+		require.NoError(t, err, "failed to create mirrored LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, lvm2.LVTypeMirror, lv.Type)
+		require.Empty(t, lv.MirrorLog)
+
+		t.Log("Creating a thin pool")
+
+		poolName := uniqueName("pool")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:   poolName,
+			VGName: vgName,
+			Size:   "64M",
+			Type:   lvm2.LVTypeThinPool,
+		})
+		require.NoError(t, err, "failed to create thin pool")
+
+		vg, err = c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.True(t, vg.PoolMetadataSpare, "expected a pool metadata spare")
+
+		t.Log("Creating a thin pool that passes discards down")
+
+		discardsPoolName := uniqueName("pool")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:     discardsPoolName,
+			VGName:   vgName,
+			Size:     "16M",
+			Type:     lvm2.LVTypeThinPool,
+			Discards: "passdown",
+		})
+		require.NoError(t, err, "failed to create thin pool")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, discardsPoolName))
+		require.NoError(t, err, "failed to get thin pool")
+		require.Equal(t, "passdown", lv.Discards)
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:     fmt.Sprintf("%s/%s", vgName, discardsPoolName),
+			Discards: "nopassdown",
+		})
+		require.NoError(t, err, "failed to change thin pool discards")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, discardsPoolName))
+		require.NoError(t, err, "failed to get thin pool")
+		require.Equal(t, "nopassdown", lv.Discards)
+
+		t.Log("Failing writes when the thin pool is full")
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:          fmt.Sprintf("%s/%s", vgName, discardsPoolName),
+			ErrorWhenFull: lvm2.Yes,
+		})
+		require.NoError(t, err, "failed to enable error when full")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, discardsPoolName))
+		require.NoError(t, err, "failed to get thin pool")
+		require.True(t, lv.IsThinPool())
+		require.Equal(t, "error", lv.WhenFull)
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:          fmt.Sprintf("%s/%s", vgName, discardsPoolName),
+			ErrorWhenFull: lvm2.No,
+		})
+		require.NoError(t, err, "failed to disable error when full")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, discardsPoolName))
+		require.NoError(t, err, "failed to get thin pool")
+		require.Equal(t, "queue", lv.WhenFull)
+
+		t.Log("Repairing the thin pool")
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:     fmt.Sprintf("%s/%s", vgName, poolName),
+			Activate: lvm2.ActivateNo,
+		})
+		require.NoError(t, err, "failed to deactivate thin pool")
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:   fmt.Sprintf("%s/%s", vgName, poolName),
+			Repair: true,
+		})
+		require.NoError(t, err, "failed to repair thin pool")
+
+		t.Log("Creating a thin pool with an explicit chunk size")
+
+		chunkedPoolName := uniqueName("chunked")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:      chunkedPoolName,
+			VGName:    vgName,
+			Size:      "32M",
+			Type:      lvm2.LVTypeThinPool,
+			ChunkSize: "256k",
+		})
+		require.NoError(t, err, "failed to create thin pool")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, chunkedPoolName))
+		require.NoError(t, err, "failed to get thin pool")
+		require.Equal(t, "256.00k", lv.ChunkSize)
+
+		t.Log("Cloning a thin logical volume")
+
+		thinName := uniqueName("thin")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:        thinName,
+			VGName:      vgName,
+			VirtualSize: "64M",
+			ThinPool:    chunkedPoolName,
+		})
+		require.NoError(t, err, "failed to create thin LV")
+
+		err = c.CloneLogicalVolume(ctx, lvm2.CloneLVOptions{
+			Origin:      fmt.Sprintf("%s/%s", vgName, thinName),
+			Name:        thinName + "_clone",
+			Independent: true,
+		})
+		require.NoError(t, err, "failed to clone thin LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s_clone", vgName, thinName))
+		require.NoError(t, err, "failed to get clone")
+		require.Equal(t, lvm2.LVTypeThin, lv.Type)
+		require.True(t, lv.IsActive())
+
+		t.Log("Listing a removed thin logical volume from history")
+
+		historyConfig := lvm2.CommonOptions{
+			Config: "metadata { record_lvs_history = 1 }",
+		}
+
+		removedName := uniqueName("removed")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			CommonOptions: historyConfig,
+			Name:          removedName,
+			VGName:        vgName,
+			VirtualSize:   "16M",
+			ThinPool:      chunkedPoolName,
+		})
+		require.NoError(t, err, "failed to create thin LV")
+
+		err = c.RemoveLogicalVolume(ctx, lvm2.RemoveLVOptions{
+			CommonOptions: historyConfig,
+			Name:          fmt.Sprintf("%s/%s", vgName, removedName),
+		})
+		require.NoError(t, err, "failed to remove thin LV")
+
+		lvs, err = c.ListLogicalVolumes(ctx, &lvm2.ListLVOptions{
+			CommonOptions: historyConfig,
+			Names:         []string{vgName},
+			History:       true,
+		})
+		require.NoError(t, err, "failed to list LVs")
+
+		var foundHistorical bool
+		for _, lv := range lvs {
+			// Historical LVs are reported with a leading dash.
+			if strings.TrimPrefix(lv.Name, "-") == removedName {
+				require.True(t, bool(lv.Historical))
+				foundHistorical = true
+			}
+		}
+		require.True(t, foundHistorical, "expected a historical record of the removed LV")
+
+		t.Log("Creating a thin pool from existing data and metadata volumes")
+
+		dataName := uniqueName("data")
+		metaName := uniqueName("meta")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:     dataName,
+			VGName:   vgName,
+			Size:     "32M",
+			Activate: lvm2.ActivateNo,
+			Zero:     lvm2.No,
+		})
+		require.NoError(t, err, "failed to create data LV")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:     metaName,
+			VGName:   vgName,
+			Size:     "8M",
+			Activate: lvm2.ActivateNo,
+			Zero:     lvm2.No,
+			PVNames:  []string{devPaths[1]},
+		})
+		require.NoError(t, err, "failed to create metadata LV")
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:         fmt.Sprintf("%s/%s", vgName, dataName),
+			Type:         lvm2.LVTypeThinPool,
+			PoolMetadata: fmt.Sprintf("%s/%s", vgName, metaName),
+		})
+		require.NoError(t, err, "failed to convert LVs to thin pool")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, dataName))
+		require.NoError(t, err, "failed to get thin pool")
+		require.Equal(t, lvm2.LVTypeThinPool, lv.Type)
+		require.Equal(t, "["+dataName+"_tmeta]", lv.MetadataLV)
+
+		t.Log("Creating a logical volume from all remaining free space")
+
+		lvName = uniqueName("remaining")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:    lvName,
+			VGName:  vgName,
+			Extents: "100%FREE",
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		vg, err = c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.Zero(t, vg.ExtentFreeCount)
+	})
+
+	t.Run("Physical extents", func(t *testing.T) {
+		vgName, devPaths := createVolumeGroup(t, c, 2)
+
+		ctx := context.Background()
+
+		lvName := uniqueName(strings.ReplaceAll(t.Name(), "/", "_"))
+
+		t.Log("Creating logical volume", lvName)
+
+		err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:   lvName,
+			VGName: vgName,
+			Size:   "100M",
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		t.Log("Moving physical extents with progress")
+
+		var progress []float64
+		err = c.MovePhysicalExtentsWithProgress(ctx, lvm2.MovePEOptions{
+			Source:      devPaths[0],
+			Destination: []string{devPaths[1]},
+		}, func(percent float64) {
+			progress = append(progress, percent)
+		})
+		require.NoError(t, err, "failed to move physical extents")
+
+		require.NotEmpty(t, progress)
+		require.Equal(t, 100.0, progress[len(progress)-1])
+
+		t.Log("Moving physical extents in the background")
+
+		err = c.MovePhysicalExtents(ctx, lvm2.MovePEOptions{
+			Source:      devPaths[1],
+			Destination: []string{devPaths[0]},
+			Background:  true,
+		})
+		require.NoError(t, err, "failed to start background move")
+
+		// The move may not have started, or may already have finished, by the
+		// time the status is first checked, so wait for the extents to leave.
+		require.Eventually(t, func() bool {
+			status, err := c.GetPhysicalVolumeMoveStatus(ctx, devPaths[1])
+			if err != nil || status.InProgress {
+				return false
+			}
+
+			pv, err := c.GetPhysicalVolume(ctx, devPaths[1])
+			return err == nil && pv.ExtentAllocCount == 0
+		}, time.Minute, time.Second)
+
+		pv, err := c.GetPhysicalVolume(ctx, devPaths[0])
+		require.NoError(t, err, "failed to get PV")
+		require.EqualValues(t, 25, pv.ExtentAllocCount)
+
+		t.Log("Moving a range of physical extents")
+
+		err = c.MovePhysicalExtents(ctx, lvm2.MovePEOptions{
+			Source:        devPaths[0],
+			SourceExtents: "0-9",
+			Destination:   []string{devPaths[1]},
+		})
+		require.NoError(t, err, "failed to move range of physical extents")
+
+		pv, err = c.GetPhysicalVolume(ctx, devPaths[1])
+		require.NoError(t, err, "failed to get PV")
+		require.EqualValues(t, 10, pv.ExtentAllocCount)
+	})
+
+	t.Run("RAID reshaping", func(t *testing.T) {
+		vgName, _ := createVolumeGroup(t, c, 4)
+
+		ctx := context.Background()
+
+		lvName := uniqueName("reshape")
+		fullName := fmt.Sprintf("%s/%s", vgName, lvName)
+
+		waitForSync := func() {
+			require.Eventually(t, func() bool {
+				lv, err := c.GetLogicalVolume(ctx, fullName)
+				return err == nil && lv.CopyPercent.Valid && lv.CopyPercent.Float64 == 100 &&
+					(lv.RAIDSyncAction == "" || lv.RAIDSyncAction == "idle")
+			}, time.Minute, time.Second)
+		}
+
+		t.Log("Creating linear logical volume", lvName)
+
+		err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:   lvName,
+			VGName: vgName,
+			Size:   "64M",
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		t.Log("Converting linear logical volume to RAID1")
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:       fullName,
+			Type:       lvm2.LVTypeRAID1,
+			Mirrors:    lvm2.PtrTo(1),
+			RegionSize: "1M",
+		})
+		require.NoError(t, err, "failed to convert LV to RAID1")
+
+		waitForSync()
+
+		t.Log("Converting RAID1 logical volume to RAID5")
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name: fullName,
+			Type: lvm2.LVTypeRAID5,
+		})
+		require.NoError(t, err, "failed to convert LV to RAID5")
+
+		lv, err := c.GetLogicalVolume(ctx, fullName)
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, strings.HasPrefix(string(lv.Type), string(lvm2.LVTypeRAID5)), "unexpected type: %s", lv.Type)
+
+		waitForSync()
+
+		t.Log("Reshaping RAID5 logical volume to three data stripes")
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:       fullName,
+			Stripes:    lvm2.PtrTo(3),
+			StripeSize: "64K",
+		})
+		require.NoError(t, err, "failed to reshape LV")
+
+		waitForSync()
+
+		lv, err = c.GetLogicalVolume(ctx, fullName)
+		require.NoError(t, err, "failed to get LV")
+		require.EqualValues(t, 3, lv.DataStripes)
+
+		t.Log("Creating a RAID5 logical volume directly")
+
+		raidName := uniqueName("raid5")
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:       raidName,
+			VGName:     vgName,
+			Size:       "48M",
+			Type:       lvm2.LVTypeRAID5,
+			Stripes:    lvm2.PtrTo(3),
+			StripeSize: "64K",
+		})
+		require.NoError(t, err, "failed to create RAID5 LV")
+
+		lv, err = c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, raidName))
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, strings.HasPrefix(string(lv.Type), string(lvm2.LVTypeRAID5)), "unexpected type: %s", lv.Type)
+		require.EqualValues(t, 3, lv.DataStripes)
+		require.EqualValues(t, 4, lv.Stripes)
+	})
+
+	t.Run("Integrity", func(t *testing.T) {
+		v, err := c.Version(context.Background())
+		require.NoError(t, err)
+
+		if !v.SupportsIntegrity() {
+			t.Skip("lvm does not support dm-integrity")
+		}
+
+		if err := exec.Command("/sbin/modprobe", "dm-integrity").Run(); err != nil {
+			t.Skip("kernel does not support dm-integrity")
+		}
+
+		vgName, _ := createVolumeGroup(t, c, 2)
+
+		ctx := context.Background()
+
+		lvName := uniqueName("integrity")
+
+		t.Log("Creating RAID1 logical volume with integrity", lvName)
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:          lvName,
+			VGName:        vgName,
+			Size:          "64M",
+			Type:          lvm2.LVTypeRAID1,
+			Mirrors:       lvm2.PtrTo(1),
+			RAIDIntegrity: lvm2.Yes,
+		})
+		require.NoError(t, err, "failed to create LV with integrity")
+
+		lv, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, lv.HasIntegrity())
+	})
+
+	t.Run("RAID repair", func(t *testing.T) {
+		vgName, devPaths := createVolumeGroup(t, c, 3)
+
+		ctx := context.Background()
+
+		lvName := uniqueName("raid1")
+		fullName := fmt.Sprintf("%s/%s", vgName, lvName)
+
+		t.Log("Creating RAID1 logical volume", lvName)
+
+		err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:    lvName,
+			VGName:  vgName,
+			Type:    lvm2.LVTypeRAID1,
+			Mirrors: lvm2.PtrTo(1),
+			Size:    "64M",
+			PVNames: devPaths[:2],
+		})
+		require.NoError(t, err, "failed to create RAID1 LV")
+
+		require.Eventually(t, func() bool {
+			lv, err := c.GetLogicalVolume(ctx, fullName)
+			return err == nil && lv.CopyPercent.Valid && lv.CopyPercent.Float64 == 100
+		}, time.Minute, time.Second)
+
+		t.Log("Removing a leg of the RAID1 logical volume", devPaths[1])
+
+		err = detachNBDDevice(devPaths[1])
+		require.NoError(t, err)
+
+		lv, err := c.GetLogicalVolume(ctx, fullName)
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, lvm2.LVHealthPartial, lv.Health())
+
+		t.Log("Repairing the RAID1 logical volume onto a fresh physical volume", devPaths[2])
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:    fullName,
+			Repair:  true,
+			PVNames: []string{devPaths[2]},
+		})
+		require.NoError(t, err, "failed to repair RAID1 LV")
+
+		require.Eventually(t, func() bool {
+			lv, err := c.GetLogicalVolume(ctx, fullName)
+			return err == nil && lv.Health() == lvm2.LVHealthOK && lv.CopyPercent.Valid && lv.CopyPercent.Float64 == 100
+		}, time.Minute, time.Second)
+
+		err = c.ReduceVolumeGroup(ctx, lvm2.ReduceVGOptions{
+			Name:          vgName,
+			RemoveMissing: true,
+		})
+		require.NoError(t, err, "failed to remove missing PV")
+
+		pvs, err := c.ListPhysicalVolumes(ctx, &lvm2.ListPVOptions{
+			Select: lvm2.Select().Eq("vg_name", vgName).String(),
+		})
+		require.NoError(t, err, "failed to list PVs")
+		require.Len(t, pvs, 2)
+	})
+
+	t.Run("Partial activation", func(t *testing.T) {
+		vgName, devPaths := createVolumeGroup(t, c, 2)
+
+		ctx := context.Background()
+
+		lvName := uniqueName("partial")
+
+		t.Log("Creating logical volume spanning both physical volumes", lvName)
+
+		err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:     lvName,
+			VGName:   vgName,
+			Size:     "1.5G",
+			Activate: lvm2.ActivateNo,
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		t.Log("Simulating a missing physical volume", devPaths[1])
+
+		err = detachNBDDevice(devPaths[1])
+		require.NoError(t, err)
+
+		vg, err := c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.True(t, vg.Attr().Partial)
+
+		t.Log("Activating volume group without partial")
+
+		err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			Name:     vgName,
+			Activate: lvm2.ActivateYes,
+		})
+		require.Error(t, err)
+
+		t.Log("Activating volume group with partial")
+
+		err = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			Name:                 vgName,
+			Activate:             lvm2.ActivateYes,
+			Partial:              true,
+			IgnoreSkippedCluster: true,
+		})
+		require.NoError(t, err, "failed to activate VG")
+
+		lv, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.True(t, lv.IsActive())
+
+		err = c.UpdateLogicalVolume(ctx, lvm2.UpdateLVOptions{
+			Name:                 fmt.Sprintf("%s/%s", vgName, lvName),
+			Activate:             lvm2.ActivateNo,
+			Partial:              true,
+			IgnoreSkippedCluster: true,
+		})
+		require.NoError(t, err, "failed to deactivate LV")
+
+		t.Log("Removing the missing physical volume, so the volume group can be cleaned up")
+
+		// The LV spans the missing PV, so it is removed along with it.
+		err = c.ReduceVolumeGroup(ctx, lvm2.ReduceVGOptions{
+			Name:          vgName,
+			RemoveMissing: true,
+			Force:         true,
+		})
+		require.NoError(t, err, "failed to remove missing PV")
+
+		vg, err = c.GetVolumeGroup(ctx, vgName)
+		require.NoError(t, err, "failed to get VG")
+		require.False(t, vg.Attr().Partial)
+	})
+
+	t.Run("Watching for changes", func(t *testing.T) {
+		vgName, _ := createVolumeGroup(t, c, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		watcher := lvm2.NewClient(lvm2.WithWatchInterval(100 * time.Millisecond))
+
+		events, err := watcher.Watch(ctx)
+		require.NoError(t, err, "failed to watch")
+
+		lvName := uniqueName("watched")
+
+		t.Log("Creating logical volume", lvName)
+
+		err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:   lvName,
+			VGName: vgName,
+			Size:   "64M",
+		})
+		require.NoError(t, err, "failed to create LV")
+
+		want := lvm2.Event{Type: lvm2.EventCreated, VG: vgName, LV: lvName}
+
+		require.Eventually(t, func() bool {
+			for {
+				select {
+				case event := <-events:
+					if event == want {
+						return true
+					}
+				default:
+					return false
+				}
+			}
+		}, 30*time.Second, 100*time.Millisecond, "expected created event")
+	})
+
+	t.Run("Write cache", func(t *testing.T) {
+		v, err := c.Version(context.Background())
+		require.NoError(t, err)
+
+		if !v.SupportsWriteCache() {
+			t.Skip("lvm does not support dm-writecache")
+		}
+
+		if err := exec.Command("/sbin/modprobe", "dm-writecache").Run(); err != nil {
+			t.Skip("kernel does not support dm-writecache")
+		}
+
+		vgName, lvName, cacheName := createCacheVolumes(t, c)
+
+		ctx := context.Background()
+
+		t.Log("Attaching write cache")
+
+		err = c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:     fmt.Sprintf("%s/%s", vgName, lvName),
+			Type:     lvm2.LVTypeWriteCache,
+			CacheVol: cacheName,
+		})
+		require.NoError(t, err, "failed to attach write cache")
+
+		lv, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, lvm2.LVTypeWriteCache, lv.Type)
+		require.Contains(t, lv.PoolLV, cacheName)
+	})
+
+	t.Run("Cache policy", func(t *testing.T) {
+		if err := exec.Command("/sbin/modprobe", "dm-cache").Run(); err != nil {
+			t.Skip("kernel does not support dm-cache")
+		}
+
+		vgName, lvName, cacheName := createCacheVolumes(t, c)
+
+		ctx := context.Background()
+
+		t.Log("Attaching cache with the smq policy")
+
+		err := c.ConvertLogicalVolumeLayout(ctx, lvm2.ConvertLVLayoutOptions{
+			Name:        fmt.Sprintf("%s/%s", vgName, lvName),
+			Type:        lvm2.LVTypeCache,
+			CacheVol:    cacheName,
+			CachePolicy: "smq",
+			CacheSettings: lvm2.CacheSettings{
+				"migration_threshold": "2048",
+			},
+		})
+		require.NoError(t, err, "failed to attach cache")
+
+		lv, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get LV")
+		require.Equal(t, lvm2.LVTypeCache, lv.Type)
+		require.Equal(t, "smq", lv.CachePolicy)
+		require.Contains(t, lv.CacheSettings, "migration_threshold=2048")
+	})
+
+	t.Run("VDO volumes", func(t *testing.T) {
+		vgName, _ := createVolumeGroup(t, c, 4)
+
+		ctx := context.Background()
+
+		lvName := uniqueName("vdo")
+
+		t.Log("Creating VDO logical volume", lvName)
+
+		err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+			Name:          lvName,
+			VGName:        vgName,
+			Size:          "3G",
+			VirtualSize:   "10G",
+			VDO:           true,
+			VDOSettings:   []string{"vdo_slab_size_mb=128"},
+			Compression:   lvm2.Yes,
+			Deduplication: lvm2.Yes,
+		})
+		if errors.Is(err, lvm2.ErrVDONotSupported) {
+			t.Skip("VDO is not supported on this system")
+		}
+		require.NoError(t, err, "failed to create VDO LV")
+
+		lv, err := c.GetLogicalVolume(ctx, fmt.Sprintf("%s/%s", vgName, lvName))
+		require.NoError(t, err, "failed to get VDO LV")
+		require.Equal(t, lvm2.LVTypeVDO, lv.Type)
+	})
+}
+
+func TestListLogicalVolumesWithOptions(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record+`
+echo '{"report":[{"lv":[{"lv_name":"lv0", "lv_size":"100.00m"}]}]}'`)))
+
+	lvs, err := c.ListLogicalVolumes(context.Background(), &lvm2.ListLVOptions{
+		Options: []string{"lv_name,lv_size", "lv_health_status"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, lvs, 1)
+	require.Equal(t, "lv0", lvs[0].Name)
+	require.Equal(t, "100.00m", lvs[0].Size)
+
+	require.Equal(t, []string{"lvs --reportformat=json --binary --options=lv_all,seg_all,vg_name,lv_name,lv_size,lv_health_status"}, cmdLines())
+}
+
+func TestScanPhysicalVolumesActivation(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record)))
+
+	ctx := context.Background()
+
+	err := c.ScanPhysicalVolumes(ctx, lvm2.ScanPVOptions{
+		Cache:    true,
+		Activate: lvm2.ActivateAuto,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"pvscan --cache --activate=ay"}, cmdLines())
+
+	t.Log("Other activation states are rejected")
+
+	err = c.ScanPhysicalVolumes(ctx, lvm2.ScanPVOptions{
+		Cache:    true,
+		Activate: lvm2.ActivateYes,
+	})
+	require.ErrorContains(t, err, "only supports autoactivation")
+
+	require.Empty(t, cmdLines(), "expected pvscan not to run")
+}
+
+func TestGetVolumeGroup(t *testing.T) {
+	ctx := context.Background()
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '  Volume group "missing" not found' >&2
+echo '  Cannot process volume group missing' >&2
+exit 5`)))
+
+	_, err := c.GetVolumeGroup(ctx, "missing")
+	require.ErrorIs(t, err, lvm2.ErrVolumeGroupNotFound)
+
+	t.Log("Other lookup failures aren't a missing volume group")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '  Devices file test.devices not found.' >&2
+exit 5`)))
+
+	_, err = c.GetVolumeGroup(ctx, "vg0")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, lvm2.ErrVolumeGroupNotFound)
+}
+
+func TestGetLogicalVolume(t *testing.T) {
+	ctx := context.Background()
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '{"report":[{"lv":[
+{"lv_uuid":"abc", "lv_name":"lv0", "vg_name":"vg0", "seg_start_pe":"0", "seg_size_pe":"25", "devices":"/dev/nbd0(0)"},
+{"lv_uuid":"abc", "lv_name":"lv0", "vg_name":"vg0", "seg_start_pe":"25", "seg_size_pe":"25", "devices":"/dev/nbd1(0)"}
+]}]}'`)))
+
+	lv, err := c.GetLogicalVolume(ctx, "vg0/lv0")
+	require.NoError(t, err)
+
+	require.Equal(t, "lv0", lv.Name)
+	require.Len(t, lv.Segments, 2)
+	require.Equal(t, "/dev/nbd0(0)", lv.Segments[0].Devices)
+	require.Equal(t, "25", lv.Segments[1].SegmentStartExtents)
+	require.Equal(t, "/dev/nbd1(0)", lv.Segments[1].Devices)
+
+	t.Log("Missing logical volume")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '  Failed to find logical volume "vg0/missing"' >&2
+exit 5`)))
+
+	_, err = c.GetLogicalVolume(ctx, "vg0/missing")
+	require.ErrorIs(t, err, lvm2.ErrLogicalVolumeNotFound)
+
+	t.Log("Missing volume group")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '  Volume group "missing" not found' >&2
+echo '  Cannot process volume group missing' >&2
+exit 5`)))
+
+	_, err = c.GetLogicalVolume(ctx, "missing/lv0")
+	require.ErrorIs(t, err, lvm2.ErrVolumeGroupNotFound)
+	require.NotErrorIs(t, err, lvm2.ErrLogicalVolumeNotFound)
+}
+
+func TestListVolumeGroupsPoolMetadataSpare(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record+`
+case "$1" in
+vgs) echo '{"report":[{"vg":[{"vg_name":"vg0"},{"vg_name":"vg1"}]}]}' ;;
+lvs) echo '{"report":[{"lv":[{"lv_name":"[lvol0_pmspare]","lv_role":"private,pool,spare","vg_name":"vg1"},{"lv_name":"lv0","lv_role":"public","vg_name":"vg0"}]}]}' ;;
+esac`)))
+
+	vgs, err := c.ListVolumeGroups(context.Background(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, vgs, 2)
+	require.False(t, vgs[0].PoolMetadataSpare)
+	require.True(t, vgs[1].PoolMetadataSpare)
+
+	require.Equal(t, []string{
+		"vgs --reportformat=json --binary --options=vg_all",
+		"lvs --reportformat=json --binary --all --options=lv_name,lv_role,vg_name vg0 vg1",
+	}, cmdLines())
+
+	t.Log("The VGs are still listed if the spares can't be looked up")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `case "$1" in
+vgs) echo '{"report":[{"vg":[{"vg_name":"vg0"}]}]}' ;;
+lvs) echo "  Skipping foreign volume group vg0" >&2; exit 5 ;;
+esac`)))
+
+	vgs, err = c.ListVolumeGroups(context.Background(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, vgs, 1)
+	require.False(t, vgs[0].PoolMetadataSpare)
+}
+
+func TestAvailable(t *testing.T) {
+	ctx := context.Background()
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  LVM version:     2.03.16(2) (2022-05-18)"`)))
+	require.NoError(t, c.Available(ctx))
+
+	t.Log("Missing executable")
+
+	c = lvm2.NewClient(lvm2.WithLVM(filepath.Join(t.TempDir(), "lvm")))
+	err := c.Available(ctx)
+	require.ErrorIs(t, err, lvm2.ErrUnavailable)
+	require.ErrorContains(t, err, "not found")
+
+	t.Log("Executable that can't be run")
+
+	lvmPath := filepath.Join(t.TempDir(), "lvm")
+	require.NoError(t, os.WriteFile(lvmPath, []byte("#!/bin/sh\n"), 0o644))
+
+	c = lvm2.NewClient(lvm2.WithLVM(lvmPath))
+	err = c.Available(ctx)
+	require.ErrorIs(t, err, lvm2.ErrUnavailable)
+	require.ErrorContains(t, err, "not permitted")
+
+	t.Log("Executable that fails")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  WARNING: Running as a non-root user." >&2; exit 5`)))
+	err = c.Available(ctx)
+	require.ErrorIs(t, err, lvm2.ErrUnavailable)
+	require.ErrorContains(t, err, "non-root")
+}
+
+func TestListConfiguredDevices(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record+`
+cat <<'EOF'
+  Device /dev/sdb IDTYPE=sys_wwid IDNAME=naa.6001405a4b3c2d1e DEVNAME=/dev/sdb PVID=Jx3cDkVBa4qVXGmQgfLEdCtQ3tWLhQ7b
+  Device /dev/nbd0 IDTYPE=devname IDNAME=/dev/nbd0 DEVNAME=/dev/nbd0 PVID=none
+EOF`)))
+
+	devices, err := c.ListConfiguredDevices(context.Background(), "test.devices")
+	require.NoError(t, err)
+	require.Equal(t, []lvm2.ConfiguredDevice{
+		{
+			IDType:  "sys_wwid",
+			IDName:  "naa.6001405a4b3c2d1e",
+			DevName: "/dev/sdb",
+			PVID:    "Jx3cDkVBa4qVXGmQgfLEdCtQ3tWLhQ7b",
+		},
+		{
+			IDType:  "devname",
+			IDName:  "/dev/nbd0",
+			DevName: "/dev/nbd0",
+		},
+	}, devices)
+
+	require.Equal(t, []string{"lvmdevices --devicesfile=test.devices"}, cmdLines())
+}
+
+func TestResolveSize(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '{"report":[{"vg":[{"vg_name":"vg0","vg_extent_size":"4.00m","vg_extent_count":"255","vg_free_count":"101"}]}]}'`)))
+
+	ctx := context.Background()
+
+	t.Log("Resolving percentages")
+
+	size, err := c.ResolveSize(ctx, "vg0", "50%FREE")
+	require.NoError(t, err)
+	require.Equal(t, 50*4*lvm2.Mebibyte, size)
+
+	size, err = c.ResolveSize(ctx, "vg0", "100%VG")
+	require.NoError(t, err)
+	require.Equal(t, 255*4*lvm2.Mebibyte, size)
+
+	t.Log("Resolving absolute sizes")
+
+	size, err = c.ResolveSize(ctx, "vg0", "2G")
+	require.NoError(t, err)
+	require.Equal(t, 2*lvm2.Gibibyte, size)
+
+	size, err = c.ResolveSize(ctx, "vg0", "5")
+	require.NoError(t, err)
+	require.Equal(t, 8*lvm2.Mebibyte, size, "expected size to be rounded up to whole extents")
+
+	t.Log("Invalid sizes")
+
+	_, err = c.ResolveSize(ctx, "vg0", "50%PVS")
+	require.ErrorContains(t, err, "unsupported")
+
+	_, err = c.ResolveSize(ctx, "vg0", "half%FREE")
+	require.Error(t, err)
+}
+
+func TestFullReport(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record+`
+cat <<'EOF'
+{
+  "report": [
+    {
+      "vg": [{"vg_name":"vg0", "vg_extent_count":"25"}],
+      "pv": [{"pv_name":"/dev/sda", "vg_name":"vg0"}],
+      "lv": [{"lv_name":"lv0", "vg_name":"vg0"}],
+      "pvseg": [
+        {"pv_name":"/dev/sda", "pvseg_start":"0", "pvseg_size":"10", "lv_name":"lv0", "vg_name":"vg0"},
+        {"pv_name":"/dev/sda", "pvseg_start":"10", "pvseg_size":"15", "lv_name":"", "vg_name":"vg0"}
+      ],
+      "seg": [{"segtype":"linear", "lv_name":"lv0", "vg_name":"vg0"}]
+    },
+    {
+      "vg": [{"vg_name":"vg1", "vg_extent_count":"25"}],
+      "pv": [{"pv_name":"/dev/sdb", "vg_name":"vg1"}],
+      "lv": [],
+      "pvseg": [{"pv_name":"/dev/sdb", "pvseg_start":"0", "pvseg_size":"25", "lv_name":"", "vg_name":"vg1"}],
+      "seg": []
+    }
+  ]
+}
+EOF`)))
+
+	report, err := c.FullReport(context.Background(), &lvm2.FullReportOptions{
+		VGNames: []string{"vg0", "vg1"},
+	})
+	require.NoError(t, err)
+
+	cmdLine := cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.True(t, strings.HasPrefix(cmdLine[0], "fullreport --reportformat=json --binary vg0 vg1 --configreport=vg"), "unexpected command line: %s", cmdLine[0])
+
+	require.Len(t, report.VGs, 2)
+	require.Equal(t, "vg0", report.VGs[0].Name)
+	require.Equal(t, "vg1", report.VGs[1].Name)
+	require.Len(t, report.PVs, 2)
+	require.Len(t, report.LVs, 1)
+	require.Equal(t, "lv0", report.LVs[0].Name)
+	require.Equal(t, []lvm2.PVSegment{
+		{PVName: "/dev/sda", Start: 0, Length: 10, LVName: "lv0", VGName: "vg0"},
+		{PVName: "/dev/sda", Start: 10, Length: 15, VGName: "vg0"},
+		{PVName: "/dev/sdb", Start: 0, Length: 25, VGName: "vg1"},
+	}, report.PVSegments)
+	require.Len(t, report.Segments, 1)
+	require.Equal(t, lvm2.LVTypeLinear, report.Segments[0].Type)
+}
+
+func TestListPhysicalVolumeSegments(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record+`
+cat <<'EOF'
+  {
+      "report": [
+          {
+              "pv": [
+                  {"pvseg_start":"0", "pvseg_size":"25", "pv_name":"/dev/sda", "lv_name":"lv0", "vg_name":"vg0"},
+                  {"pvseg_start":"25", "pvseg_size":"10", "pv_name":"/dev/sda", "lv_name":"", "vg_name":"vg0"},
+                  {"pvseg_start":"35", "pvseg_size":"220", "pv_name":"/dev/sda", "lv_name":"lv1", "vg_name":"vg0"}
+              ]
+          }
+      ]
+  }
+EOF`)))
+
+	segments, err := c.ListPhysicalVolumeSegments(context.Background(), "/dev/sda")
+	require.NoError(t, err)
+	require.Equal(t, []lvm2.PVSegment{
+		{PVName: "/dev/sda", Start: 0, Length: 25, LVName: "lv0", VGName: "vg0"},
+		{PVName: "/dev/sda", Start: 25, Length: 10, VGName: "vg0"},
+		{PVName: "/dev/sda", Start: 35, Length: 220, LVName: "lv1", VGName: "vg0"},
+	}, segments)
+	require.False(t, segments[0].Free())
+	require.True(t, segments[1].Free())
+
+	require.Equal(t, []string{"pvs --reportformat=json --binary --segments --options=pvseg_all,pv_name,lv_name,vg_name /dev/sda"}, cmdLines())
+}
+
+func TestCreateLogicalVolumeAllocationTags(t *testing.T) {
+	opts := lvm2.CreateLVOptions{
+		Name:           "lv0",
+		VGName:         "vg0",
+		Size:           "16M",
+		PVNames:        []string{"/dev/sda"},
+		AllocationTags: []string{"ssd", "nvme"},
+	}
+
+	require.Equal(t, []string{"lvcreate", "--yes", "--name=lv0", "--size=16M", "vg0", "/dev/sda", "@ssd", "@nvme"}, lvm2.CommandLine(opts))
+
+	// The caller's options are left untouched.
+	require.Equal(t, []string{"/dev/sda"}, opts.PVNames)
+}
+
+func TestCreateLogicalVolumeWithoutVolumeGroup(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `exit 1`)))
+
+	err := c.CreateLogicalVolume(context.Background(), lvm2.CreateLVOptions{
+		Name:           "lv0",
+		Size:           "16M",
+		PVNames:        []string{"/dev/sda"},
+		AllocationTags: []string{"ssd"},
+	})
+	require.ErrorContains(t, err, "volume group name is required")
+}
+
+func TestSetThinPoolAutoextendValidation(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `exit 1`)))
+
+	ctx := context.Background()
+
+	require.ErrorContains(t, c.SetThinPoolAutoextend(ctx, "vg0", 40, 20), "must be between 50 and 100")
+	require.ErrorContains(t, c.SetThinPoolAutoextend(ctx, "vg0", 101, 20), "must be between 50 and 100")
+	require.ErrorContains(t, c.SetThinPoolAutoextend(ctx, "vg0", 80, 0), "must be positive")
+}
+
+func TestSetThinPoolAutoextendProfile(t *testing.T) {
+	profileDir := t.TempDir()
+	record, cmdLines := recordArgs(t)
+
+	// vgchange fails for the volume group named "broken".
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, fmt.Sprintf(`case "$1" in
+lvmconfig) echo 'profile_dir="%s"' ;;
+vgchange)
+	%s
+	case "$*" in *broken*) exit 5 ;; esac ;;
+esac`, profileDir, record))))
+
+	ctx := context.Background()
+
+	profilePath := filepath.Join(profileDir, lvm2.ThinPoolAutoextendProfile("vg0")+".profile")
+
+	err := c.SetThinPoolAutoextend(ctx, "vg0", 80, 20)
+	require.NoError(t, err)
+
+	profile, err := os.ReadFile(profilePath)
+	require.NoError(t, err)
+	require.Contains(t, string(profile), "thin_pool_autoextend_threshold = 80")
+
+	require.Equal(t, []string{"vgchange --yes --metadataprofile=autoextend-vg0 vg0"}, cmdLines())
+
+	t.Log("Clearing removes the profile")
+
+	err = c.ClearThinPoolAutoextend(ctx, "vg0")
+	require.NoError(t, err)
+
+	require.NoFileExists(t, profilePath)
+
+	require.Equal(t, []string{"vgchange --yes --detachprofile vg0"}, cmdLines())
+
+	t.Log("A profile that can't be attached isn't left behind")
+
+	err = c.SetThinPoolAutoextend(ctx, "broken", 80, 20)
+	require.Error(t, err)
+
+	require.NoFileExists(t, filepath.Join(profileDir, lvm2.ThinPoolAutoextendProfile("broken")+".profile"))
+}
+
+func TestCommandOutput(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `case "$1" in
+lvcreate) echo '  Logical volume "lv0" created.' ;;
+pvck) echo '  label_header at 512' ;;
+esac`)))
+
+	ctx := context.Background()
+
+	out, err := c.CreateLogicalVolumeWithOutput(ctx, lvm2.CreateLVOptions{
+		Name:   "lv0",
+		VGName: "vg0",
+		Size:   "16M",
+	})
+	require.NoError(t, err)
+	require.Equal(t, `  Logical volume "lv0" created.`+"\n", out)
+
+	out, err = c.CheckPhysicalVolumeWithOutput(ctx, lvm2.CheckPVOptions{
+		Name: "/dev/sda",
+		Dump: "headers",
+	})
+	require.NoError(t, err)
+	require.Contains(t, out, "label_header")
+}
+
+func TestRawArgs(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record)))
+
+	ctx := context.Background()
+
+	err := c.CreateVolumeGroup(ctx, lvm2.CreateVGOptions{
+		CommonOptions: lvm2.CommonOptions{
+			NoHints: true,
+			RawArgs: []string{"--metadatatype=lvm2", "--verbose"},
+		},
+		Name:    "vg0",
+		PVNames: []string{"/dev/sda", "/dev/sdb"},
+		Zero:    lvm2.Yes,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"vgcreate --yes --nohints --zero=y vg0 /dev/sda /dev/sdb --metadatatype=lvm2 --verbose"}, cmdLines())
+
+	_, err = c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
+		CommonOptions: lvm2.CommonOptions{
+			RawArgs: []string{"--foreign"},
+		},
+	})
+	require.Error(t, err)
+
+	cmdLine := cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.True(t, strings.HasSuffix(cmdLine[0], " --foreign"), "unexpected command line: %s", cmdLine[0])
+}
+
+func TestRenameLogicalVolume(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record)))
+
+	ctx := context.Background()
+
+	t.Log("Renaming within the same volume group")
+
+	err := c.RenameLogicalVolume(ctx, lvm2.RenameLVOptions{
+		From: "vg0/old",
+		To:   "new",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"lvrename --yes vg0/old new"}, cmdLines())
+
+	err = c.RenameLogicalVolume(ctx, lvm2.RenameLVOptions{
+		From: "vg0/old",
+		To:   "vg0/new",
+	})
+	require.NoError(t, err)
+
+	t.Log("Renaming across volume groups")
+
+	err = c.RenameLogicalVolume(ctx, lvm2.RenameLVOptions{
+		From: "vg0/old",
+		To:   "vg1/new",
+	})
+	require.ErrorContains(t, err, "different volume group")
+
+	err = c.RenameLogicalVolume(ctx, lvm2.RenameLVOptions{
+		From: "old",
+		To:   "new",
+	})
+	require.ErrorContains(t, err, "vg/lv")
+}
+
+func TestReduceLogicalVolumeValidation(t *testing.T) {
+	recordLVs, lvsCmdLines := recordArgs(t)
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, fmt.Sprintf(`if [ "$1" = "lvs" ]; then
+	%s
+	echo '{"report":[{"lv":[{"lv_name":"lv0","vg_name":"vg0","lv_size":"104857600B"}]}]}'
+	exit 0
+fi
+%s`, recordLVs, record))))
+
+	ctx := context.Background()
+
+	reduce := func(size string) error {
+		return c.ReduceLogicalVolume(ctx, lvm2.ReduceLVOptions{
+			Name:     "vg0/lv0",
+			Size:     size,
+			Validate: true,
+		})
+	}
+
+	t.Log("Shrinking the logical volume")
+
+	require.NoError(t, reduce("64M"))
+	require.NoError(t, reduce("-36m"))
+
+	require.Equal(t, []string{
+		"lvreduce --yes --size=64M vg0/lv0",
+		"lvreduce --yes --size=-36m vg0/lv0",
+	}, cmdLines())
+
+	t.Log("Requesting the current size")
+
+	require.ErrorIs(t, reduce("100M"), lvm2.ErrNoChange)
+	require.ErrorIs(t, reduce("-0"), lvm2.ErrNoChange)
+
+	t.Log("Requesting a larger size")
+
+	require.ErrorIs(t, reduce("1g"), lvm2.ErrWouldGrow)
+	require.ErrorIs(t, reduce("+4m"), lvm2.ErrWouldGrow)
+
+	require.Empty(t, cmdLines(), "expected lvreduce not to run")
+
+	t.Log("Raw arguments are kept when looking up the current size")
+
+	err := c.ReduceLogicalVolume(ctx, lvm2.ReduceLVOptions{
+		CommonOptions: lvm2.CommonOptions{
+			RawArgs: []string{"--config=devices/scan_lvs=0"},
+		},
+		Name:     "vg0/lv0",
+		Size:     "64M",
+		Validate: true,
+	})
+	require.NoError(t, err)
+
+	require.Contains(t, lvsCmdLines(), "lvs --reportformat=json --binary --options=lv_all,seg_all,vg_name vg0/lv0 --config=devices/scan_lvs=0 --units=b")
+
+	t.Log("Validation is opt-in")
+
+	err = c.ReduceLogicalVolume(ctx, lvm2.ReduceLVOptions{
+		Name: "vg0/lv0",
+		Size: "1g",
+	})
+	require.NoError(t, err)
+}
+
+func TestResizePhysicalVolumeValidation(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	// 64 4MiB extents are allocated after a 1MiB data area offset, with a gap
+	// of 32 free extents between the two segments.
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, fmt.Sprintf(`case "$1 $2 $3 $4" in
+*--segments*)
+	echo '{"report":[{"pv":[
+		{"pvseg_start":"0","pvseg_size":"32","lv_name":"lv0"},
+		{"pvseg_start":"32","pvseg_size":"32","lv_name":""},
+		{"pvseg_start":"64","pvseg_size":"32","lv_name":"lv1"},
+		{"pvseg_start":"96","pvseg_size":"160","lv_name":""}
+	]}]}'
+	exit 0 ;;
+pvs*)
+	echo '{"report":[{"pv":[{"pv_name":"/dev/sda","pe_start":"1048576B","pv_used":"268435456B","vg_extent_size":"4194304B"}]}]}'
+	exit 0 ;;
+esac
+%s`, record))))
+
+	ctx := context.Background()
+
+	t.Log("Shrinking while leaving room for the allocated extents")
+
+	err := c.ResizePhysicalVolume(ctx, lvm2.ResizePVOptions{
+		Name:                  "/dev/sda",
+		SetPhysicalVolumeSize: "512M",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"pvresize --yes --setphysicalvolumesize=512M /dev/sda"}, cmdLines())
+
+	t.Log("Shrinking below the allocated extents")
+
+	err = c.ResizePhysicalVolume(ctx, lvm2.ResizePVOptions{
+		Name:                  "/dev/sda",
+		SetPhysicalVolumeSize: "256M",
+	})
+	require.ErrorIs(t, err, lvm2.ErrWouldTruncate)
+
+	t.Log("Shrinking to the used space, which would truncate the last segment")
+
+	err = c.ResizePhysicalVolume(ctx, lvm2.ResizePVOptions{
+		Name:                  "/dev/sda",
+		SetPhysicalVolumeSize: "300M",
+	})
+	require.ErrorIs(t, err, lvm2.ErrWouldTruncate)
+
+	require.Empty(t, cmdLines(), "expected pvresize not to run")
+
+	t.Log("Forcing an unsafe shrink")
+
+	err = c.ResizePhysicalVolume(ctx, lvm2.ResizePVOptions{
+		Name:                  "/dev/sda",
+		SetPhysicalVolumeSize: "256M",
+		Force:                 true,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"pvresize --yes --setphysicalvolumesize=256M /dev/sda"}, cmdLines())
+}
+
+func TestCloneLogicalVolume(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	fake := func(lvType string) string {
+		return fakeLVM(t, fmt.Sprintf(`if [ "$1" = "lvs" ]; then
+	echo '{"report":[{"lv":[{"lv_name":"origin","vg_name":"vg0","segtype":"%s"}]}]}'
+	exit 0
+fi
+%s`, lvType, record))
+	}
+
+	ctx := context.Background()
+
+	t.Log("Cloning a thin logical volume")
+
+	c := lvm2.NewClient(lvm2.WithLVM(fake("thin")))
+
+	err := c.CloneLogicalVolume(ctx, lvm2.CloneLVOptions{
+		Origin:      "vg0/origin",
+		Name:        "clone",
+		Independent: true,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"lvcreate --yes --name=clone --activate=y --setactivationskip=n --snapshot vg0/origin"}, cmdLines())
+
+	t.Log("Cloning a thick logical volume")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fake("linear")))
+
+	err = c.CloneLogicalVolume(ctx, lvm2.CloneLVOptions{
+		Origin: "vg0/origin",
+		Name:   "clone",
+		Size:   "100M",
+	})
+	require.NoError(t, err)
+
+	cmdLine := cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.Contains(t, cmdLine[0], "--snapshot")
+	require.Contains(t, cmdLine[0], "--size=100M")
+
+	err = c.CloneLogicalVolume(ctx, lvm2.CloneLVOptions{
+		Origin:      "vg0/origin",
+		Name:        "clone",
+		Size:        "100M",
+		Independent: true,
+	})
+	require.Error(t, err)
+
+	err = c.CloneLogicalVolume(ctx, lvm2.CloneLVOptions{
+		Origin: "vg0/origin",
+		Name:   "clone",
+	})
+	require.ErrorContains(t, err, "size is required")
+}
+
+func loadNBDModule() error {
+	cmd := exec.Command("/sbin/modprobe", "nbd", "max_part=16")
+	return cmd.Run()
+}
+
+func createImage(imagePath string) error {
+	cmd := exec.Command("qemu-img", "create", "-f", "qcow2", imagePath, "1G")
+	return cmd.Run()
+}
+
+func attachNBDDevice(imagePath string) (string, error) {
+	for i := 0; i < 16; i++ {
+		devPath := fmt.Sprintf("/dev/nbd%d", i)
+		cmd := exec.Command("qemu-nbd", "-c", devPath, imagePath)
+		err := cmd.Run()
+		if err == nil {
+			return devPath, nil
+		}
+	}
+
+	return "", fmt.Errorf("no free nbd device found")
+}
+
+func detachNBDDevice(devPath string) error {
+	cmd := exec.Command("qemu-nbd", "-d", devPath)
+	return cmd.Run()
+}
+
+// createVolumeGroup creates a volume group backed by the given number of
+// virtual block devices, which are all cleaned up when the test completes.
+func createVolumeGroup(t *testing.T, c *lvm2.Client, devices int) (string, []string) {
+	t.Helper()
+
+	return createVolumeGroupWithOptions(t, c, devices, lvm2.CreateVGOptions{})
+}
+
+// createVolumeGroupWithOptions is like createVolumeGroup but allows the options
+// used to create the volume group to be customized. The name and physical
+// volumes are filled in automatically.
+func createVolumeGroupWithOptions(t *testing.T, c *lvm2.Client, devices int, opts lvm2.CreateVGOptions) (string, []string) {
+	t.Helper()
+
+	var devPaths []string
+	for i := 0; i < devices; i++ {
+		imagePath := filepath.Join(t.TempDir(), ".qcow2")
+		err := createImage(imagePath)
+		require.NoError(t, err)
+
+		devPath, err := attachNBDDevice(imagePath)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			err := detachNBDDevice(devPath)
+			require.NoError(t, err)
+		})
+
+		devPaths = append(devPaths, devPath)
+	}
+
+	ctx := context.Background()
+
+	vgName := uniqueName(strings.ReplaceAll(t.Name(), "/", "_"))
+
+	opts.Name = vgName
+	opts.PVNames = devPaths
+
+	err := c.CreateVolumeGroup(ctx, opts)
+	require.NoError(t, err, "failed to create VG")
+
+	t.Cleanup(func() {
+		_ = c.UpdateVolumeGroup(ctx, lvm2.UpdateVGOptions{
+			Name:     vgName,
+			Activate: lvm2.ActivateNo,
+		})
+
+		_ = c.RemoveVolumeGroup(ctx, lvm2.RemoveVGOptions{
+			Name:  vgName,
+			Force: true,
+		})
+	})
+
+	return vgName, devPaths
+}
+
+// createCacheVolumes creates a volume group with an inactive logical volume
+// and a smaller cache volume to attach to it, each on its own physical volume.
+func createCacheVolumes(t *testing.T, c *lvm2.Client) (vgName, lvName, cacheName string) {
+	t.Helper()
+
+	vgName, devPaths := createVolumeGroup(t, c, 2)
+
+	ctx := context.Background()
+
+	lvName = uniqueName("slow")
+	cacheName = uniqueName("fast")
+
+	t.Log("Creating logical volume and cache volume", lvName, cacheName)
+
+	err := c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+		Name:     lvName,
+		VGName:   vgName,
+		Size:     "64M",
+		PVNames:  []string{devPaths[0]},
+		Activate: lvm2.ActivateNo,
+	})
+	require.NoError(t, err, "failed to create LV")
+
+	err = c.CreateLogicalVolume(ctx, lvm2.CreateLVOptions{
+		Name:     cacheName,
+		VGName:   vgName,
+		Size:     "16M",
+		PVNames:  []string{devPaths[1]},
+		Activate: lvm2.ActivateNo,
+	})
+	require.NoError(t, err, "failed to create cache volume")
+
+	return vgName, lvName, cacheName
+}
+
+// filesystemSize returns the size in bytes of the ext4 filesystem on a device.
+func filesystemSize(devPath string) (int64, error) {
+	out, err := exec.Command("dumpe2fs", "-h", devPath).Output()
+	if err != nil {
+		return 0, err
+	}
+
+	var blockCount, blockSize int64
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "Block count":
+			blockCount, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		case "Block size":
+			blockSize, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return blockCount * blockSize, nil
+}
+
+// fakeLVM writes a shell script that stands in for the lvm executable.
+func fakeLVM(t *testing.T, script string) string {
+	t.Helper()
+
+	lvmPath := filepath.Join(t.TempDir(), "lvm")
+	err := os.WriteFile(lvmPath, []byte("#!/bin/sh\n"+script+"\n"), 0o755)
+	require.NoError(t, err)
+
+	return lvmPath
+}
+
+// recordArgs returns a line of shell for a fake lvm that records the arguments
+// it is run with, and a function that returns the command lines recorded since
+// it was last called.
+func recordArgs(t *testing.T) (string, func() []string) {
+	t.Helper()
+
+	argsPath := filepath.Join(t.TempDir(), "args")
+
+	return fmt.Sprintf(`echo "$@" >> %s`, argsPath), func() []string {
+		cmdLines, err := os.ReadFile(argsPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		require.NoError(t, err)
+		require.NoError(t, os.Remove(argsPath))
+
+		return strings.Split(strings.TrimSuffix(string(cmdLines), "\n"), "\n")
+	}
 }
 
 func uniqueName(prefix string) string {
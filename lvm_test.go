@@ -0,0 +1,60 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLVMClient overrides only the methods a test needs. Calling any other
+// method panics, since the embedded interface is nil.
+type fakeLVMClient struct {
+	lvm2.LVM
+	vgs []lvm2.VolumeGroup
+}
+
+func (f *fakeLVMClient) ListVolumeGroups(_ context.Context, _ *lvm2.ListVGOptions) ([]lvm2.VolumeGroup, error) {
+	return f.vgs, nil
+}
+
+func TestLVMInterface(t *testing.T) {
+	// Code under test depends on the interface rather than *lvm2.Client.
+	vgNames := func(ctx context.Context, c lvm2.LVM) ([]string, error) {
+		vgs, err := c.ListVolumeGroups(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var names []string
+		for _, vg := range vgs {
+			names = append(names, vg.Name)
+		}
+
+		return names, nil
+	}
+
+	names, err := vgNames(context.Background(), &fakeLVMClient{
+		vgs: []lvm2.VolumeGroup{{Name: "vg0"}, {Name: "vg1"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"vg0", "vg1"}, names)
+}
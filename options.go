@@ -17,6 +17,11 @@
 
 package lvm2
 
+import (
+	"sync"
+	"time"
+)
+
 // ClientOption is an option for configuring the lvm2 client.
 type ClientOption func(*Client)
 
@@ -26,3 +31,88 @@ func WithLVM(path string) ClientOption {
 		c.lvmPath = path
 	}
 }
+
+// Set a timeout applied to each lvm command, when the context passed to a
+// method doesn't already have a deadline.
+func WithDefaultTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.defaultTimeout = d
+	}
+}
+
+// Set the devices file used by lvm commands (from /etc/lvm/devices/), unless
+// overridden by a DevicesFile option.
+func WithDevicesFile(path string) ClientOption {
+	return func(c *Client) {
+		c.devicesFile = path
+	}
+}
+
+// Serialize commands run by the client, so that concurrent callers don't
+// contend for lvm's own locks.
+func WithSerializedCommands() ClientOption {
+	return func(c *Client) {
+		c.mu = &sync.Mutex{}
+	}
+}
+
+// Retry commands that fail due to lock contention with another command, up to
+// the given number of attempts in total. The delay between attempts starts at
+// backoff and doubles after each attempt.
+func WithRetry(attempts int, backoff time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryAttempts = attempts
+		c.retryBackoff = backoff
+	}
+}
+
+// Set how often Watch polls for changes, see DefaultWatchInterval.
+func WithWatchInterval(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.watchInterval = d
+	}
+}
+
+// Don't automatically confirm the prompts of commands with --yes. The commands
+// aren't connected to a terminal, so any command that would prompt for
+// confirmation fails instead.
+func WithoutAutoConfirm() ClientOption {
+	return func(c *Client) {
+		c.noAutoConfirm = true
+	}
+}
+
+// Disable locking for all commands, as if the NoLocking option had been set.
+// This allows volume groups to be inspected where the lock directory isn't
+// writeable, eg. on a read-only root or in a rescue shell. Concurrent commands
+// may see inconsistent metadata, so this should be used with care.
+func WithoutLocking() ClientOption {
+	return func(c *Client) {
+		c.noLocking = true
+	}
+}
+
+// Include foreign volume groups, owned by other hosts, in reports as if the
+// Foreign option had been set.
+func WithForeignVGs() ClientOption {
+	return func(c *Client) {
+		c.foreign = true
+	}
+}
+
+// Include shared volume groups in reports, even when lvmlockd isn't running,
+// as if the Shared option had been set.
+func WithSharedVGs() ClientOption {
+	return func(c *Client) {
+		c.shared = true
+	}
+}
+
+// Call fn with each warning that lvm writes to stderr while running a command
+// that succeeds, eg. "Sum of all thin volume sizes exceeds the size of thin
+// pool". Warnings from commands that fail are included in the returned error.
+func WithWarningHandler(fn func(warning string)) ClientOption {
+	return func(c *Client) {
+		c.warningHandler = fn
+	}
+}
@@ -0,0 +1,332 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaultTimeout(t *testing.T) {
+	c := lvm2.NewClient(
+		lvm2.WithLVM(fakeLVM(t, "exec sleep 10")),
+		lvm2.WithDefaultTimeout(50*time.Millisecond),
+	)
+
+	start := time.Now()
+	_, err := c.Version(context.Background())
+	require.ErrorIs(t, err, lvm2.ErrTimeout)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	t.Log("A deadline on the context takes precedence")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	t.Cleanup(cancel)
+
+	_, err = c.Version(ctx)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, lvm2.ErrTimeout)
+}
+
+func TestWithDevicesFile(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(
+		lvm2.WithLVM(fakeLVM(t, record+`
+echo '{"report":[{"pv":[]}]}'`)),
+		lvm2.WithDevicesFile("test.devices"),
+	)
+
+	ctx := context.Background()
+
+	_, err := c.ListPhysicalVolumes(ctx, &lvm2.ListPVOptions{
+		CommonOptions: lvm2.CommonOptions{
+			Devices: []string{"/dev/sda", "/dev/sdb"},
+		},
+	})
+	require.NoError(t, err)
+
+	cmdLine := cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.Contains(t, cmdLine[0], "pvs --devicesfile=test.devices ")
+	require.Contains(t, cmdLine[0], "--devices=/dev/sda")
+	require.Contains(t, cmdLine[0], "--devices=/dev/sdb")
+
+	t.Log("A per-call devices file takes precedence")
+
+	_, err = c.ListPhysicalVolumes(ctx, &lvm2.ListPVOptions{
+		CommonOptions: lvm2.CommonOptions{
+			DevicesFile: "other.devices",
+		},
+	})
+	require.NoError(t, err)
+
+	cmdLine = cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.NotContains(t, cmdLine[0], "test.devices")
+	require.Contains(t, cmdLine[0], "--devicesfile=other.devices")
+}
+
+func TestWithForeignAndSharedVGs(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	lvmPath := fakeLVM(t, record+`
+echo '{"report":[{"vg":[]}]}'`)
+
+	ctx := context.Background()
+
+	c := lvm2.NewClient(lvm2.WithLVM(lvmPath))
+
+	_, err := c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
+		Foreign: true,
+		Shared:  true,
+	})
+	require.NoError(t, err)
+
+	cmdLine := cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.Contains(t, cmdLine[0], " --foreign")
+	require.Contains(t, cmdLine[0], " --shared")
+
+	t.Log("Client defaults")
+
+	c = lvm2.NewClient(
+		lvm2.WithLVM(lvmPath),
+		lvm2.WithForeignVGs(),
+		lvm2.WithSharedVGs(),
+	)
+
+	_, err = c.ListVolumeGroups(ctx, nil)
+	require.NoError(t, err)
+
+	cmdLine = cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.True(t, strings.HasPrefix(cmdLine[0], "vgs --foreign --shared "), "unexpected command line: %s", cmdLine[0])
+
+	_, err = c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
+		Foreign: true,
+	})
+	require.NoError(t, err)
+
+	cmdLine = cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.Equal(t, 1, strings.Count(cmdLine[0], "--foreign"))
+
+	t.Log("Only report commands are affected")
+
+	err = c.RemoveVolumeGroup(ctx, lvm2.RemoveVGOptions{
+		Name: "vg0",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"vgremove --yes vg0"}, cmdLines())
+
+	t.Log("Looking up pool metadata spares in the listed VGs")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, record+`
+echo '{"report":[{"vg":[{"vg_name":"vg0"}]}]}'`)))
+
+	_, err = c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
+		CommonOptions: lvm2.CommonOptions{
+			RawArgs: []string{"--nameprefixes"},
+		},
+		Foreign: true,
+		Shared:  true,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"vgs --reportformat=json --binary --options=vg_all --foreign --shared --nameprefixes",
+		"lvs --reportformat=json --binary --all --options=lv_name,lv_role,vg_name --foreign --shared vg0",
+	}, cmdLines())
+}
+
+func TestWithoutLocking(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(
+		lvm2.WithLVM(fakeLVM(t, record+`
+echo '{"report":[{"vg":[]}]}'`)),
+		lvm2.WithoutLocking(),
+	)
+
+	ctx := context.Background()
+
+	_, err := c.ListVolumeGroups(ctx, nil)
+	require.NoError(t, err)
+
+	cmdLine := cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.True(t, strings.HasPrefix(cmdLine[0], "vgs --nolocking "), "unexpected command line: %s", cmdLine[0])
+
+	t.Log("Locking isn't disabled twice")
+
+	_, err = c.ListVolumeGroups(ctx, &lvm2.ListVGOptions{
+		CommonOptions: lvm2.CommonOptions{
+			NoLocking: true,
+		},
+	})
+	require.NoError(t, err)
+
+	cmdLine = cmdLines()
+	require.Len(t, cmdLine, 1)
+	require.Equal(t, 1, strings.Count(cmdLine[0], "--nolocking"))
+
+	t.Log("Commands that don't lock are unaffected")
+
+	_, _ = c.Version(ctx)
+
+	require.Equal(t, []string{"version"}, cmdLines())
+}
+
+func TestWithSerializedCommands(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "lock")
+
+	// The fake fails if another invocation is running at the same time.
+	c := lvm2.NewClient(
+		lvm2.WithLVM(fakeLVM(t, fmt.Sprintf(`mkdir %[1]s || exit 1
+sleep 0.01
+echo '{"report":[{"vg":[]}]}'
+rmdir %[1]s`, lockPath))),
+		lvm2.WithSerializedCommands(),
+	)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := c.ListVolumeGroups(context.Background(), nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	countPath := filepath.Join(t.TempDir(), "count")
+
+	// The fake fails with the given error for the first two invocations.
+	fake := func(msg string) string {
+		return fakeLVM(t, fmt.Sprintf(`echo x >> %s
+if [ "$(wc -l < %[1]s)" -le 2 ]; then
+	echo "  %s" >&2
+	exit 5
+fi
+echo '{"report":[{"vg":[]}]}'`, countPath, msg))
+	}
+
+	invocations := func() int {
+		count, err := os.ReadFile(countPath)
+		require.NoError(t, err)
+
+		return strings.Count(string(count), "x")
+	}
+
+	c := lvm2.NewClient(
+		lvm2.WithLVM(fake("Can't get lock for vg0.")),
+		lvm2.WithRetry(3, 10*time.Millisecond),
+	)
+
+	_, err := c.ListVolumeGroups(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, invocations())
+
+	t.Log("Other errors are not retried")
+
+	require.NoError(t, os.Remove(countPath))
+
+	c = lvm2.NewClient(
+		lvm2.WithLVM(fake("Volume group \"vg0\" not found")),
+		lvm2.WithRetry(3, 10*time.Millisecond),
+	)
+
+	_, err = c.ListVolumeGroups(context.Background(), nil)
+	require.ErrorContains(t, err, "not found")
+	require.Equal(t, 1, invocations())
+}
+
+func TestWithWarningHandler(t *testing.T) {
+	var warnings []string
+
+	c := lvm2.NewClient(
+		lvm2.WithLVM(fakeLVM(t, `echo "  WARNING: Sum of all thin volume sizes (2.00 GiB) exceeds the size of thin pool vg0/pool (1.00 GiB)." >&2
+echo "  Logical volume \"thin\" created." >&2`)),
+		lvm2.WithWarningHandler(func(warning string) {
+			warnings = append(warnings, warning)
+		}),
+	)
+
+	err := c.CreateLogicalVolume(context.Background(), lvm2.CreateLVOptions{
+		Name:        "thin",
+		VGName:      "vg0",
+		VirtualSize: "2G",
+		ThinPool:    "pool",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Sum of all thin volume sizes (2.00 GiB) exceeds the size of thin pool vg0/pool (1.00 GiB)."}, warnings)
+}
+
+func TestWithoutAutoConfirm(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	// The fake prompts for confirmation unless --yes is given.
+	lvmPath := fakeLVM(t, record+`
+case " $* " in
+*" --yes "*) exit 0 ;;
+esac
+printf 'Do you really want to remove volume group "vg0"? [y/n]: '
+read answer || { echo "  Volume group \"vg0\" not removed." >&2; exit 5; }`)
+
+	ctx := context.Background()
+
+	c := lvm2.NewClient(lvm2.WithLVM(lvmPath))
+
+	err := c.RemoveVolumeGroup(ctx, lvm2.RemoveVGOptions{Name: "vg0"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"vgremove --yes vg0"}, cmdLines())
+
+	t.Log("Prompts fail rather than being confirmed")
+
+	c = lvm2.NewClient(
+		lvm2.WithLVM(lvmPath),
+		lvm2.WithoutAutoConfirm(),
+	)
+
+	err = c.RemoveVolumeGroup(ctx, lvm2.RemoveVGOptions{Name: "vg0"})
+	require.ErrorContains(t, err, "not removed")
+
+	require.Equal(t, []string{"vgremove vg0"}, cmdLines())
+}
@@ -0,0 +1,69 @@
+//go:build unix
+
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelKillsProcessGroup(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "helper.pid")
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, fmt.Sprintf(`sleep 30 &
+echo $! > %s
+wait`, pidPath))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	t.Cleanup(cancel)
+
+	start := time.Now()
+	_, err := c.Version(ctx)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	pidBytes, err := os.ReadFile(pidPath)
+	require.NoError(t, err)
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(pidBytes)))
+	require.NoError(t, err)
+
+	t.Log("Checking the helper process was killed")
+
+	require.Eventually(t, func() bool {
+		stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		if err != nil {
+			return true
+		}
+
+		// A killed process may linger as a zombie until it is reaped.
+		fields := strings.Fields(string(stat))
+		return len(fields) > 2 && fields[2] == "Z"
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,58 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readProgress reads the periodic progress lines printed by long running
+// commands such as pvmove, calling progress for each one.
+func readProgress(r io.Reader, progress func(percent float64)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if percent, ok := parseProgress(scanner.Text()); ok {
+			progress(percent)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseProgress parses a progress line, eg. "/dev/sdb: Moved: 42.50%".
+func parseProgress(line string) (float64, bool) {
+	i := strings.LastIndex(line, ":")
+	if i < 0 {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(line[i+1:])
+	if !strings.HasSuffix(value, "%") {
+		return 0, false
+	}
+
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return percent, true
+}
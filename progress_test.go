@@ -0,0 +1,78 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovePhysicalExtentsWithProgress(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  /dev/nbd0: Moved: 0.00%"
+echo "  /dev/nbd0: Moved: 42.50%"
+echo "  /dev/nbd0: Moved: 100.00%"`)))
+
+	var progress []float64
+	err := c.MovePhysicalExtentsWithProgress(context.Background(), lvm2.MovePEOptions{
+		Source: "/dev/nbd0",
+	}, func(percent float64) {
+		progress = append(progress, percent)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 42.5, 100}, progress)
+
+	t.Log("Errors include the command's stderr")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  /dev/nbd0: Moved: 10.00%"
+echo "  Insufficient free space" >&2
+exit 5`)))
+
+	progress = nil
+	err = c.MovePhysicalExtentsWithProgress(context.Background(), lvm2.MovePEOptions{
+		Source: "/dev/nbd0",
+	}, func(percent float64) {
+		progress = append(progress, percent)
+	})
+	require.ErrorContains(t, err, "Insufficient free space")
+	require.Equal(t, []float64{10}, progress)
+}
+
+func TestMovePhysicalExtentsWithProgressAbort(t *testing.T) {
+	record, cmdLines := recordArgs(t)
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, fmt.Sprintf(`case "$*" in
+*--abort*) %s ;;
+*) echo "  /dev/nbd0: Moved: 10.00%%"; sleep 10 ;;
+esac`, record))), lvm2.WithDefaultTimeout(500*time.Millisecond))
+
+	err := c.MovePhysicalExtentsWithProgress(context.Background(), lvm2.MovePEOptions{
+		CommonOptions: lvm2.CommonOptions{
+			RawArgs: []string{"--config=devices/scan_lvs=0"},
+		},
+		Source:        "/dev/nbd0",
+		SourceExtents: "0-99",
+	}, func(float64) {})
+	require.ErrorIs(t, err, lvm2.ErrTimeout)
+
+	require.Equal(t, []string{"pvmove --yes --abort /dev/nbd0 --config=devices/scan_lvs=0"}, cmdLines())
+}
@@ -0,0 +1,178 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxOutputInError limits how much of the output of an lvm command is included
+// in the error returned when it can't be parsed.
+const maxOutputInError = 512
+
+// parseError returns an error for lvm output that couldn't be parsed. The raw
+// output is included as it is often the best clue as to what went wrong.
+func parseError(err error, out []byte) error {
+	if len(out) > maxOutputInError {
+		out = append(out[:maxOutputInError:maxOutputInError], "..."...)
+	}
+
+	return fmt.Errorf("failed to parse lvm output: %w: %q", err, out)
+}
+
+// ParsePVReport parses the JSON output of pvs (--reportformat=json).
+func ParsePVReport(reportJSON []byte) ([]PhysicalVolume, error) {
+	return parseReport[PhysicalVolume](reportJSON, "pv")
+}
+
+// ParseVGReport parses the JSON output of vgs (--reportformat=json).
+func ParseVGReport(reportJSON []byte) ([]VolumeGroup, error) {
+	return parseReport[VolumeGroup](reportJSON, "vg")
+}
+
+// ParseLVReport parses the JSON output of lvs (--reportformat=json).
+func ParseLVReport(reportJSON []byte) ([]LogicalVolume, error) {
+	return parseReport[LogicalVolume](reportJSON, "lv")
+}
+
+// parseReport parses the named section (eg. "lv") of the first report in the
+// JSON output of a report command.
+func parseReport[T any](reportJSON []byte, section string) ([]T, error) {
+	var report struct {
+		Report []map[string]json.RawMessage `json:"report"`
+	}
+	if err := json.Unmarshal(reportJSON, &report); err != nil {
+		return nil, parseError(err, reportJSON)
+	}
+
+	if len(report.Report) == 0 {
+		return nil, nil
+	}
+
+	sectionJSON, ok := report.Report[0][section]
+	if !ok {
+		return nil, nil
+	}
+
+	var items []T
+	if err := json.Unmarshal(sectionJSON, &items); err != nil {
+		return nil, parseError(err, reportJSON)
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	return items, nil
+}
+
+// decodeReport incrementally decodes a JSON report, calling fn with the
+// decoder positioned at each element of the named section (eg. "lv").
+func decodeReport(r io.Reader, section string, fn func(dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to parse lvm output: %w", err)
+		}
+
+		if key != "report" {
+			if err := skipValue(dec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+
+		for dec.More() {
+			if err := expectDelim(dec, '{'); err != nil {
+				return err
+			}
+
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return fmt.Errorf("failed to parse lvm output: %w", err)
+				}
+
+				if key != section {
+					if err := skipValue(dec); err != nil {
+						return err
+					}
+					continue
+				}
+
+				if err := expectDelim(dec, '['); err != nil {
+					return err
+				}
+
+				for dec.More() {
+					if err := fn(dec); err != nil {
+						return err
+					}
+				}
+
+				if err := expectDelim(dec, ']'); err != nil {
+					return err
+				}
+			}
+
+			if err := expectDelim(dec, '}'); err != nil {
+				return err
+			}
+		}
+
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to parse lvm output: %w", err)
+	}
+
+	if tok != delim {
+		return fmt.Errorf("failed to parse lvm output: expected %q but got %v", delim, tok)
+	}
+
+	return nil
+}
+
+func skipValue(dec *json.Decoder) error {
+	var v json.RawMessage
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("failed to parse lvm output: %w", err)
+	}
+
+	return nil
+}
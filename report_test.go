@@ -0,0 +1,203 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterLogicalVolumes(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `cat <<'EOF'
+  {
+      "report": [
+          {
+              "lv": [
+                  {"lv_name":"first", "vg_name":"vg", "lv_size":"100.00m"},
+                  {"lv_name":"second", "vg_name":"vg", "lv_size":"200.00m"},
+                  {"lv_name":"third", "vg_name":"vg", "lv_size":"300.00m"}
+              ]
+          }
+      ]
+  }
+EOF`)))
+
+	ctx := context.Background()
+
+	var names []string
+	err := c.IterLogicalVolumes(ctx, nil, func(lv lvm2.LogicalVolume) error {
+		names = append(names, lv.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second", "third"}, names)
+
+	t.Log("Stopping after the first logical volume")
+
+	errStop := errors.New("stop")
+
+	names = nil
+	err = c.IterLogicalVolumes(ctx, nil, func(lv lvm2.LogicalVolume) error {
+		names = append(names, lv.Name)
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, []string{"first"}, names)
+}
+
+func TestIterLogicalVolumesCommandError(t *testing.T) {
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo "  Volume group \"missing\" not found" >&2
+exit 5`)))
+
+	err := c.IterLogicalVolumes(context.Background(), nil, func(lv lvm2.LogicalVolume) error {
+		return nil
+	})
+	require.ErrorContains(t, err, "exit status 5")
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestListMalformedOutput(t *testing.T) {
+	ctx := context.Background()
+
+	t.Log("Malformed output from a successful command")
+
+	c := lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '  {"report": [{"vg": [{"vg_name":"vg0"'`)))
+
+	_, err := c.ListVolumeGroups(ctx, nil)
+	require.ErrorContains(t, err, "failed to parse lvm output")
+	require.ErrorContains(t, err, `{\"report\": [{\"vg\": [{\"vg_name\":\"vg0\"`)
+
+	t.Log("Partial output from a failed command")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `echo '  {"report": [{"lv": ['
+echo '  Volume group "vg0" not found' >&2
+exit 5`)))
+
+	_, err = c.ListLogicalVolumes(ctx, &lvm2.ListLVOptions{
+		Names: []string{"vg0"},
+	})
+	require.ErrorContains(t, err, `Volume group "vg0" not found`)
+	require.NotContains(t, err.Error(), "failed to parse lvm output")
+
+	t.Log("Long malformed output is truncated")
+
+	c = lvm2.NewClient(lvm2.WithLVM(fakeLVM(t, `head -c 4096 /dev/zero | tr '\0' x`)))
+
+	_, err = c.ListPhysicalVolumes(ctx, nil)
+	require.ErrorContains(t, err, "failed to parse lvm output")
+	require.Less(t, len(err.Error()), 1024)
+}
+
+func TestParseReports(t *testing.T) {
+	readFixture := func(name string) []byte {
+		data, err := os.ReadFile(filepath.Join("testdata", "reports", name))
+		require.NoError(t, err)
+
+		return data
+	}
+
+	t.Run("Physical volumes", func(t *testing.T) {
+		pvs, err := lvm2.ParsePVReport(readFixture("pvs.json"))
+		require.NoError(t, err)
+		require.Len(t, pvs, 2)
+
+		require.Equal(t, "/dev/nbd0", pvs[0].Name)
+		require.Equal(t, "vg0", pvs[0].VGName)
+		require.EqualValues(t, 43, pvs[0].Major)
+		require.EqualValues(t, 255, pvs[0].ExtentCount)
+		require.EqualValues(t, 96, pvs[0].ExtentAllocCount)
+		require.True(t, bool(pvs[0].Allocatable))
+		require.False(t, bool(pvs[0].Missing))
+
+		require.Equal(t, "[unknown]", pvs[1].Name)
+		require.True(t, bool(pvs[1].Missing))
+		require.True(t, pvs[1].Attr().Missing)
+	})
+
+	t.Run("Volume groups", func(t *testing.T) {
+		vgs, err := lvm2.ParseVGReport(readFixture("vgs.json"))
+		require.NoError(t, err)
+		require.Len(t, vgs, 2)
+
+		require.Equal(t, "vg0", vgs[0].Name)
+		require.Equal(t, "4.00m", vgs[0].ExtentSize)
+		require.EqualValues(t, 389, vgs[0].ExtentFreeCount)
+		require.EqualValues(t, 4, vgs[0].LVCount)
+		require.False(t, bool(vgs[0].Partial))
+
+		require.Equal(t, "backup", vgs[1].Name)
+		require.True(t, bool(vgs[1].Partial))
+		require.EqualValues(t, 1, vgs[1].MissingPVCount)
+		require.Equal(t, "offsite", vgs[1].Tags)
+	})
+
+	t.Run("Logical volumes", func(t *testing.T) {
+		lvs, err := lvm2.ParseLVReport(readFixture("lvs.json"))
+		require.NoError(t, err)
+		require.Len(t, lvs, 4)
+
+		tests := []struct {
+			name     string
+			lvType   lvm2.LVType
+			active   bool
+			open     bool
+			snapshot bool
+			thinPool bool
+		}{
+			{name: "data", lvType: lvm2.LVTypeLinear, active: true, open: true},
+			{name: "pool", lvType: lvm2.LVTypeThinPool, active: true, open: true, thinPool: true},
+			{name: "data_snap", lvType: lvm2.LVTypeLinear, active: true, snapshot: true},
+			{name: "archive", lvType: lvm2.LVTypeRAID1},
+		}
+
+		for i, tt := range tests {
+			lv := lvs[i]
+
+			require.Equal(t, tt.name, lv.Name)
+			require.Equal(t, "vg0", lv.VGName)
+			require.Equal(t, tt.lvType, lv.Type, tt.name)
+			require.Equal(t, tt.active, lv.IsActive(), tt.name)
+			require.Equal(t, tt.open, lv.IsOpen(), tt.name)
+			require.Equal(t, tt.snapshot, lv.IsSnapshot(), tt.name)
+			require.Equal(t, tt.thinPool, lv.IsThinPool(), tt.name)
+		}
+
+		require.Equal(t, "data", lvs[2].Origin)
+		require.Equal(t, "queue", lvs[1].WhenFull)
+		require.EqualValues(t, 1, lvs[1].ThinCount)
+	})
+
+	t.Run("Empty and malformed reports", func(t *testing.T) {
+		lvs, err := lvm2.ParseLVReport([]byte(`{"report":[{"lv":[]}]}`))
+		require.NoError(t, err)
+		require.Nil(t, lvs)
+
+		lvs, err = lvm2.ParseLVReport([]byte(`{"report":[]}`))
+		require.NoError(t, err)
+		require.Nil(t, lvs)
+
+		_, err = lvm2.ParseLVReport([]byte(`{"report":[{"lv":[{"seg_count":"many"}]}]}`))
+		require.ErrorContains(t, err, "failed to parse lvm output")
+	})
+}
@@ -0,0 +1,153 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var unquotedSelectValueRegexp = regexp.MustCompile(`^[A-Za-z0-9_.+\-/:%]+$`)
+
+// Selector builds the selection criteria passed to lvm with --select, eg.
+//
+//	Select().Eq("vg_tags", tag).And().Gt("lv_size", "1g").String()
+//
+// Values are quoted when they contain characters that lvm would otherwise
+// interpret. lvm can't escape quotes, so a value containing both single and
+// double quotes can't be selected on, and is reported by Err.
+type Selector struct {
+	sb  strings.Builder
+	err error
+}
+
+// Select starts building new selection criteria.
+func Select() *Selector {
+	return &Selector{}
+}
+
+// Eq matches when the field is equal to the value.
+func (s *Selector) Eq(field, value string) *Selector {
+	return s.compare(field, "=", value)
+}
+
+// Ne matches when the field is not equal to the value.
+func (s *Selector) Ne(field, value string) *Selector {
+	return s.compare(field, "!=", value)
+}
+
+// Gt matches when the field is greater than the value.
+func (s *Selector) Gt(field, value string) *Selector {
+	return s.compare(field, ">", value)
+}
+
+// Ge matches when the field is greater than or equal to the value.
+func (s *Selector) Ge(field, value string) *Selector {
+	return s.compare(field, ">=", value)
+}
+
+// Lt matches when the field is less than the value.
+func (s *Selector) Lt(field, value string) *Selector {
+	return s.compare(field, "<", value)
+}
+
+// Le matches when the field is less than or equal to the value.
+func (s *Selector) Le(field, value string) *Selector {
+	return s.compare(field, "<=", value)
+}
+
+// Match matches when the field matches the regular expression.
+func (s *Selector) Match(field, regex string) *Selector {
+	return s.compare(field, "=~", regex)
+}
+
+// NotMatch matches when the field does not match the regular expression.
+func (s *Selector) NotMatch(field, regex string) *Selector {
+	return s.compare(field, "!~", regex)
+}
+
+// And requires both the preceding and following criteria to match.
+func (s *Selector) And() *Selector {
+	s.sb.WriteString(" && ")
+	return s
+}
+
+// Or requires either the preceding or following criteria to match.
+func (s *Selector) Or() *Selector {
+	s.sb.WriteString(" || ")
+	return s
+}
+
+// Not negates the following criteria.
+func (s *Selector) Not() *Selector {
+	s.sb.WriteString("!")
+	return s
+}
+
+// Group adds nested criteria enclosed in parentheses.
+func (s *Selector) Group(inner *Selector) *Selector {
+	if s.err == nil {
+		s.err = inner.err
+	}
+
+	s.sb.WriteString("(")
+	s.sb.WriteString(inner.String())
+	s.sb.WriteString(")")
+	return s
+}
+
+// String returns the criteria in the form accepted by the Select options.
+func (s *Selector) String() string {
+	return s.sb.String()
+}
+
+// Err returns the first value that couldn't be quoted, if any, in which case the
+// criteria shouldn't be passed to lvm.
+func (s *Selector) Err() error {
+	return s.err
+}
+
+func (s *Selector) compare(field, op, value string) *Selector {
+	s.sb.WriteString(field)
+	s.sb.WriteString(op)
+	quoted, err := quoteSelectValue(value)
+	if err != nil && s.err == nil {
+		s.err = fmt.Errorf("invalid value for %s: %w", field, err)
+	}
+
+	s.sb.WriteString(quoted)
+	return s
+}
+
+func quoteSelectValue(value string) (string, error) {
+	if unquotedSelectValueRegexp.MatchString(value) {
+		return value, nil
+	}
+
+	if strings.Contains(value, `"`) {
+		if strings.Contains(value, "'") {
+			// Left malformed, so that lvm rejects it if Err isn't checked.
+			return `"` + value + `"`, fmt.Errorf("value contains both single and double quotes: %s", value)
+		}
+
+		return "'" + value + "'", nil
+	}
+
+	return `"` + value + `"`, nil
+}
@@ -0,0 +1,51 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	require.Equal(t, "vg_tags=mytag && lv_size>1g",
+		lvm2.Select().Eq("vg_tags", "mytag").And().Gt("lv_size", "1g").String())
+
+	require.Equal(t, `lv_name="my volume" || lv_name!=""`,
+		lvm2.Select().Eq("lv_name", "my volume").Or().Ne("lv_name", "").String())
+
+	require.Equal(t, `vg_name=vg0 && !(lv_role=~"^private" || lv_attr=~'a"b')`,
+		lvm2.Select().Eq("vg_name", "vg0").And().Not().Group(
+			lvm2.Select().Match("lv_role", "^private").Or().Match("lv_attr", `a"b`),
+		).String())
+
+	require.Equal(t, "move_pv=/dev/nbd0 && copy_percent<=50.00",
+		lvm2.Select().Eq("move_pv", "/dev/nbd0").And().Le("copy_percent", "50.00").String())
+
+	t.Log("Values that can't be quoted")
+
+	sel := lvm2.Select().Eq("lv_name", "lv0").And().Eq("lv_tags", `it's "quoted"`)
+	require.ErrorContains(t, sel.Err(), "both single and double quotes")
+
+	sel = lvm2.Select().Not().Group(lvm2.Select().Match("lv_tags", `'"`))
+	require.Error(t, sel.Err())
+
+	require.NoError(t, lvm2.Select().Eq("lv_tags", `it's`).Err())
+}
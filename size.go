@@ -0,0 +1,87 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Size is a size in bytes.
+type Size uint64
+
+const (
+	Byte     Size = 1
+	Sector   Size = 512
+	Kibibyte Size = 1 << 10
+	Mebibyte Size = 1 << 20
+	Gibibyte Size = 1 << 30
+	Tebibyte Size = 1 << 40
+	Pebibyte Size = 1 << 50
+	Exbibyte Size = 1 << 60
+)
+
+// sizeUnits maps lvm unit suffixes to their size. Lower case units are powers
+// of 1024 and upper case units are powers of 1000.
+var sizeUnits = map[byte]Size{
+	'b': Byte, 'B': Byte,
+	's': Sector, 'S': Sector,
+	'k': Kibibyte, 'K': 1e3,
+	'm': Mebibyte, 'M': 1e6,
+	'g': Gibibyte, 'G': 1e9,
+	't': Tebibyte, 'T': 1e12,
+	'p': Pebibyte, 'P': 1e15,
+	'e': Exbibyte, 'E': 1e18,
+}
+
+// ParseSize parses a size as reported by lvm, eg. "4.00m" or "<1.99g". Sizes
+// without a unit are in mebibytes, as with lvm's own size arguments. Sizes
+// that lvm has marked as rounded with a leading '<' or '>' are parsed as if
+// they were exact.
+func ParseSize(s string) (Size, error) {
+	v := strings.TrimLeft(strings.TrimSpace(s), "<>")
+	if v == "" {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	unit := Mebibyte
+	if u, ok := sizeUnits[v[len(v)-1]]; ok {
+		unit = u
+		v = v[:len(v)-1]
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	return Size(math.Round(f * float64(unit))), nil
+}
+
+// parseSizeArg parses a size argument, as given to an lvm command rather than
+// reported by one. Size arguments are always binary units, regardless of case.
+func parseSizeArg(s string) (Size, error) {
+	return ParseSize(strings.ToLower(s))
+}
+
+// String formats the size in bytes, in a form accepted by lvm size arguments.
+func (s Size) String() string {
+	return strconv.FormatUint(uint64(s), 10) + "b"
+}
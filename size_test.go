@@ -0,0 +1,51 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	for s, expected := range map[string]lvm2.Size{
+		"0":       0,
+		"4.00m":   4 * lvm2.Mebibyte,
+		"16.00m":  16 * lvm2.Mebibyte,
+		"<1.99g":  2136746230,
+		"128.00k": 128 * lvm2.Kibibyte,
+		"2048s":   lvm2.Mebibyte,
+		"512b":    512,
+		"1.50T":   1500000000000,
+		"8":       8 * lvm2.Mebibyte,
+		" 1.00t ": lvm2.Tebibyte,
+	} {
+		size, err := lvm2.ParseSize(s)
+		require.NoError(t, err, s)
+		require.Equal(t, expected, size, s)
+	}
+
+	for _, s := range []string{"", "<", "m", "abc", "-1m", "1.0x"} {
+		_, err := lvm2.ParseSize(s)
+		require.Error(t, err, s)
+	}
+
+	require.Equal(t, "4194304b", (4 * lvm2.Mebibyte).String())
+}
@@ -0,0 +1,161 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// waitDelay is how long to wait for a cancelled command's output to close.
+const waitDelay = 5 * time.Second
+
+// errAbandoned is returned when closing a stream whose command was killed
+// because its output was not read to the end.
+var errAbandoned = errors.New("output abandoned")
+
+// runStream starts an lvm command and returns its stdout, so that output can
+// be consumed as it is produced. Closing the stream waits for the command to
+// exit, and returns an error including the captured stderr if it failed.
+func (c *Client) runStream(ctx context.Context, cmdArgs ...string) (io.ReadCloser, error) {
+	if c.noAutoConfirm && len(cmdArgs) > 1 && cmdArgs[1] == "--yes" {
+		cmdArgs = append([]string{cmdArgs[0]}, cmdArgs[2:]...)
+	}
+
+	if c.devicesFile != "" && acceptsDevicesFile(cmdArgs) {
+		cmdArgs = append([]string{cmdArgs[0], "--devicesfile=" + c.devicesFile}, cmdArgs[1:]...)
+	}
+	if c.noLocking && acceptsNoLocking(cmdArgs) {
+		cmdArgs = append([]string{cmdArgs[0], "--nolocking"}, cmdArgs[1:]...)
+	}
+	cmdArgs = c.withReportDefaults(cmdArgs)
+
+	s := &stream{onWarning: c.warningHandler}
+
+	if c.mu != nil {
+		c.mu.Lock()
+		s.unlock = c.mu.Unlock
+	}
+
+	if _, ok := ctx.Deadline(); !ok && c.defaultTimeout > 0 {
+		s.timeout = c.defaultTimeout
+		s.ctx, s.cancel = context.WithTimeout(ctx, s.timeout)
+	} else {
+		s.ctx, s.cancel = context.WithCancel(ctx)
+	}
+
+	s.cmd = exec.CommandContext(s.ctx, c.lvmPath, cmdArgs...)
+	setProcessGroup(s.cmd)
+	// Don't wait forever on output pipes held open by an escaped helper.
+	s.cmd.WaitDelay = waitDelay
+	s.cmd.Stderr = &s.errOut
+
+	var err error
+	s.stdout, err = s.cmd.StdoutPipe()
+	if err != nil {
+		s.release()
+		return nil, err
+	}
+
+	if err := s.cmd.Start(); err != nil {
+		s.release()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// stream is the stdout of a running lvm command.
+type stream struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	timeout   time.Duration
+	cmd       *exec.Cmd
+	stdout    io.ReadCloser
+	errOut    bytes.Buffer
+	eof       bool
+	unlock    func()
+	onWarning func(string)
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	n, err := s.stdout.Read(p)
+	if errors.Is(err, io.EOF) {
+		s.eof = true
+	}
+
+	return n, err
+}
+
+// Close waits for the command to exit, killing it first if its output was not
+// read to the end.
+func (s *stream) Close() error {
+	defer s.release()
+
+	abandoned := !s.eof
+	if abandoned {
+		s.cancel()
+	}
+
+	if err := s.cmd.Wait(); err != nil {
+		if s.timeout > 0 && errors.Is(s.ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s: %s", ErrTimeout, s.timeout, s.errOut.String())
+		}
+
+		if abandoned {
+			return fmt.Errorf("%w: %w", errAbandoned, err)
+		}
+
+		return fmt.Errorf("%w: %s", err, s.errOut.String())
+	}
+
+	if s.onWarning != nil {
+		for _, warning := range parseWarnings(s.errOut.String()) {
+			s.onWarning(warning)
+		}
+	}
+
+	return nil
+}
+
+// parseWarnings extracts the warnings that lvm wrote to stderr, without their
+// "WARNING: " prefix.
+func parseWarnings(stderr string) []string {
+	var warnings []string
+	for _, line := range strings.Split(stderr, "\n") {
+		if warning, ok := strings.CutPrefix(strings.TrimSpace(line), "WARNING: "); ok {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings
+}
+
+func (s *stream) release() {
+	s.cancel()
+
+	if s.unlock != nil {
+		s.unlock()
+	}
+}
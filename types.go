@@ -19,10 +19,17 @@ package lvm2
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 )
 
-// BoolString is a JSON type that treats "1" as true and "0" as false.
+// BoolString is a JSON type for binary report fields. Reports are requested
+// with --binary, so these are "1" for true and "0" for false, or "-1" if the
+// value is unknown. Reports produced without --binary (eg. with a --nobinary
+// raw argument) use the name of the field for true, eg. "allocatable", and an
+// empty string for false, so these are accepted too.
 type BoolString bool
 
 func (b *BoolString) UnmarshalJSON(data []byte) error {
@@ -32,10 +39,10 @@ func (b *BoolString) UnmarshalJSON(data []byte) error {
 	}
 
 	switch v {
-	case "1":
-		*b = true
-	default:
+	case "", "0", "-1", "unknown":
 		*b = false
+	default:
+		*b = true
 	}
 
 	return nil
@@ -63,6 +70,33 @@ func (i *IntString) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// NullFloatString is a JSON type for strings that are actually floats, which
+// may be empty when the value is not applicable.
+type NullFloatString struct {
+	Float64 float64
+	Valid   bool // Valid is true if the value was not empty.
+}
+
+func (f *NullFloatString) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v == "" {
+		*f = NullFloatString{}
+		return nil
+	}
+
+	fval, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return err
+	}
+	*f = NullFloatString{Float64: fval, Valid: true}
+
+	return nil
+}
+
 // YesNo is a boolean type that marshals to "y" or "n".
 type YesNo bool
 
@@ -78,6 +112,124 @@ func (yn *YesNo) MarshalArg() string {
 	return "n"
 }
 
+// Activation is an activation state for the --activate flag.
+type Activation string
+
+const (
+	// ActivateYes activates the LV or VG.
+	ActivateYes Activation = "y"
+	// ActivateNo deactivates the LV or VG.
+	ActivateNo Activation = "n"
+	// ActivateExclusive activates the LV exclusively on this host.
+	ActivateExclusive Activation = "ey"
+	// ActivateLocal activates the LV on this host only.
+	ActivateLocal Activation = "ly"
+	// ActivateAuto activates the LV only if autoactivation is enabled.
+	ActivateAuto Activation = "ay"
+)
+
+func (a Activation) MarshalArg() string {
+	return string(a)
+}
+
+// UnmarshalJSON also accepts true and false, which is how activation states
+// were encoded when they could only be yes or no.
+func (a *Activation) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var yn bool
+	if err := json.Unmarshal(data, &yn); err == nil {
+		*a = ActivateNo
+		if yn {
+			*a = ActivateYes
+		}
+
+		return nil
+	}
+
+	var state string
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid activation state: %s", data)
+	}
+
+	*a = Activation(state)
+	return nil
+}
+
+// Activation modes for the ActivationMode option (--activationmode), which
+// control whether LVs with missing PVs can be activated.
+const (
+	// ActivationModeComplete only activates LVs with no missing PVs.
+	ActivationModeComplete = "complete"
+	// ActivationModeDegraded also activates RAID LVs with missing PVs, as long
+	// as enough PVs are present for the data to be complete.
+	ActivationModeDegraded = "degraded"
+	// ActivationModePartial activates any LV with missing PVs, with the missing
+	// parts replaced by an error target.
+	ActivationModePartial = "partial"
+)
+
+// LVType is the segment type of an LV, eg. for the --type flag.
+type LVType string
+
+const (
+	LVTypeLinear     LVType = "linear"
+	LVTypeStriped    LVType = "striped"
+	LVTypeMirror     LVType = "mirror"
+	LVTypeRAID0      LVType = "raid0"
+	LVTypeRAID1      LVType = "raid1"
+	LVTypeRAID4      LVType = "raid4"
+	LVTypeRAID5      LVType = "raid5"
+	LVTypeRAID6      LVType = "raid6"
+	LVTypeRAID10     LVType = "raid10"
+	LVTypeSnapshot   LVType = "snapshot"
+	LVTypeThin       LVType = "thin"
+	LVTypeThinPool   LVType = "thin-pool"
+	LVTypeCache      LVType = "cache"
+	LVTypeCachePool  LVType = "cache-pool"
+	LVTypeWriteCache LVType = "writecache"
+	LVTypeVDO        LVType = "vdo"
+	LVTypeVDOPool    LVType = "vdo-pool"
+	LVTypeIntegrity  LVType = "integrity"
+	LVTypeError      LVType = "error"
+	LVTypeZero       LVType = "zero"
+)
+
+func (t LVType) MarshalArg() string {
+	return string(t)
+}
+
+// CacheSettings are tunables for a dm-cache policy, eg. "migration_threshold",
+// for the --cachesettings flag.
+type CacheSettings map[string]string
+
+func (cs CacheSettings) MarshalArg() string {
+	keys := make([]string, 0, len(cs))
+	for k := range cs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	settings := make([]string, 0, len(keys))
+	for _, k := range keys {
+		settings = append(settings, k+"="+cs[k])
+	}
+
+	return strings.Join(settings, " ")
+}
+
+// cacheSettings returns nil for empty settings, so that the --cachesettings
+// flag is skipped rather than given an empty value.
+func cacheSettings(cs CacheSettings) CacheSettings {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	return cs
+}
+
 func PtrTo[T any](v T) *T {
 	return &v
 }
@@ -113,94 +265,165 @@ type PhysicalVolume struct {
 	DeviceID               string     `json:"pv_device_id"`      // Device ID such as the WWID.
 	DeviceIDType           string     `json:"pv_device_id_type"` // Type of the device ID, such as WWID.
 	VGName                 string     `json:"vg_name"`           // Name of the VG the PV belongs to.
+	ExtentSize             string     `json:"vg_extent_size"`    // Size of Physical Extents in the VG the PV belongs to in current units.
 }
 
 // ListPVOptions provides options for listing PVs (pvs).
 type ListPVOptions struct {
 	CommonOptions
-	Names                []string `arg:"0"`                    // Specific PVs to display.
-	All                  bool     `arg:"all"`                  // Display devices not initialized by LVM.
-	Select               string   `arg:"select"`               // Filters objects based on criteria.
-	Foreign              bool     `arg:"foreign"`              // Lists foreign VGs.
-	IgnoreLockingFailure bool     `arg:"ignorelockingfailure"` // Whether to proceed in read-only mode after lock failures.
-	ReadOnly             bool     `arg:"readonly"`             // Read metadata without locks.
-	Shared               bool     `arg:"shared"`               // Displays shared VGs without active lvmlockd.
+	Names                []string `arg:"0" json:"names,omitempty"`                                   // Specific PVs to display.
+	All                  bool     `arg:"all" json:"all,omitempty"`                                   // Display devices not initialized by LVM.
+	Select               string   `arg:"select" json:"select,omitempty"`                             // Filters objects based on criteria, see Select.
+	Foreign              bool     `arg:"foreign" json:"foreign,omitempty"`                           // Lists foreign VGs.
+	IgnoreLockingFailure bool     `arg:"ignorelockingfailure" json:"ignoreLockingFailure,omitempty"` // Whether to proceed in read-only mode after lock failures.
+	ReadOnly             bool     `arg:"readonly" json:"readOnly,omitempty"`                         // Read metadata without locks.
+	Shared               bool     `arg:"shared" json:"shared,omitempty"`                             // Displays shared VGs without active lvmlockd.
+	Options              []string `arg:"options" json:"options,omitempty"`                           // Additional fields to report, alongside the default fields.
 }
 
 // CreatePVOptions provides options for creating PVs (pvcreate).
 type CreatePVOptions struct {
 	CommonOptions
-	Name                  string `arg:"0"`                     // Device or PV to create.
-	Force                 bool   `arg:"force"`                 // Override checks and protections.
-	UUID                  string `arg:"uuid"`                  // Specific UUID for the device.
-	Zero                  *YesNo `arg:"zero"`                  // Wipe first 4 sectors of the device unless RestoreFile or UUID is given.
-	DataAlignment         string `arg:"dataalignment"`         // Align PV data's start, may be shifted by DataAlignmentOffset.
-	DataAlignmentOffset   string `arg:"dataalignmentoffset"`   // Additional shift for PV data's start.
-	BootloaderAreaSize    string `arg:"bootloaderareasize"`    // Reserved space for the bootloader.
-	LabelSector           *int   `arg:"labelsector"`           // Sector for the LVM2 identifier.
-	MetadataCopies        *int   `arg:"pvmetadatacopies"`      // Number of metadata areas on a PV.
-	MetadataSize          string `arg:"metadatasize"`          // Space for each VG metadata area.
-	MetadataIgnore        *YesNo `arg:"metadataignore"`        // If set, metadata won't be stored on the PV.
-	NoRestoreFile         bool   `arg:"norestorefile"`         // Specify UUID without a metadata backup.
-	SetPhysicalVolumeSize string `arg:"setphysicalvolumesize"` // Manually set the PV size.
-	RestoreFile           string `arg:"restorefile"`           // Align physical extents based on file's content with UUID.
+	Name                  string `arg:"0" json:"name,omitempty"`                                      // Device or PV to create.
+	Force                 bool   `arg:"force" json:"force,omitempty"`                                 // Override checks and protections.
+	UUID                  string `arg:"uuid" json:"uuid,omitempty"`                                   // Specific UUID for the device.
+	Zero                  *YesNo `arg:"zero" json:"zero,omitempty"`                                   // Wipe first 4 sectors of the device unless RestoreFile or UUID is given.
+	DataAlignment         string `arg:"dataalignment" json:"dataAlignment,omitempty"`                 // Align PV data's start, may be shifted by DataAlignmentOffset.
+	DataAlignmentOffset   string `arg:"dataalignmentoffset" json:"dataAlignmentOffset,omitempty"`     // Additional shift for PV data's start.
+	BootloaderAreaSize    string `arg:"bootloaderareasize" json:"bootloaderAreaSize,omitempty"`       // Reserved space for the bootloader.
+	LabelSector           *int   `arg:"labelsector" json:"labelSector,omitempty"`                     // Sector for the LVM2 identifier.
+	MetadataCopies        *int   `arg:"pvmetadatacopies" json:"metadataCopies,omitempty"`             // Number of metadata areas on a PV.
+	MetadataSize          string `arg:"metadatasize" json:"metadataSize,omitempty"`                   // Space for each VG metadata area.
+	MetadataIgnore        *YesNo `arg:"metadataignore" json:"metadataIgnore,omitempty"`               // If set, metadata won't be stored on the PV.
+	NoRestoreFile         bool   `arg:"norestorefile" json:"noRestoreFile,omitempty"`                 // Specify UUID without a metadata backup.
+	SetPhysicalVolumeSize string `arg:"setphysicalvolumesize" json:"setPhysicalVolumeSize,omitempty"` // Manually set the PV size.
+	RestoreFile           string `arg:"restorefile" json:"restoreFile,omitempty"`                     // Align physical extents based on file's content with UUID.
 }
 
 // UpdatePVOptions provides options to modify PVs (pvchange).
 type UpdatePVOptions struct {
 	CommonOptions
-	Name           string   `arg:"0"`              // Device or PV to modify.
-	Force          bool     `arg:"force"`          // Override checks and protections.
-	AutoBackup     *YesNo   `arg:"autobackup"`     // Auto backup metadata after changes.
-	All            bool     `arg:"all"`            // Modify all visible PVs.
-	Allocatable    *YesNo   `arg:"allocatable"`    // Toggle physical extents allocation.
-	UUID           bool     `arg:"uuid"`           // Generate a new UUID for the PV.
-	AddTags        []string `arg:"addtag"`         // Add tag/s to the PV.
-	DelTags        []string `arg:"deltag"`         // Remove tag/s from the PV.
-	MetadataIgnore *YesNo   `arg:"metadataignore"` // If set, metadata won't be stored on the PV.
-	Select         string   `arg:"select"`         // Filter objects based on criteria.
+	Name           string   `arg:"0" json:"name,omitempty"`                        // Device or PV to modify.
+	Force          bool     `arg:"force" json:"force,omitempty"`                   // Override checks and protections.
+	AutoBackup     *YesNo   `arg:"autobackup" json:"autoBackup,omitempty"`         // Auto backup metadata after changes.
+	All            bool     `arg:"all" json:"all,omitempty"`                       // Modify all visible PVs.
+	Allocatable    *YesNo   `arg:"allocatable" json:"allocatable,omitempty"`       // Toggle physical extents allocation.
+	UUID           bool     `arg:"uuid" json:"uuid,omitempty"`                     // Generate a new UUID for the PV.
+	AddTags        []string `arg:"addtag" json:"addTags,omitempty"`                // Add tag/s to the PV.
+	DelTags        []string `arg:"deltag" json:"delTags,omitempty"`                // Remove tag/s from the PV.
+	MetadataIgnore *YesNo   `arg:"metadataignore" json:"metadataIgnore,omitempty"` // If set, metadata won't be stored on the PV.
+	Select         string   `arg:"select" json:"select,omitempty"`                 // Filter objects based on criteria.
 }
 
 // RemovePVOptions provides options for removing PVs (pvremove).
 type RemovePVOptions struct {
 	CommonOptions
-	Name  string `arg:"0"`     // Device or PV to remove.
-	Force bool   `arg:"force"` // Overrides checks and protections.
+	Name  string `arg:"0" json:"name,omitempty"`      // Device or PV to remove.
+	Force bool   `arg:"force" json:"force,omitempty"` // Overrides checks and protections.
 }
 
 // CheckPVOptions provides options for checking PVs (pvck).
 type CheckPVOptions struct {
 	CommonOptions
-	Name             string   `arg:"0"`                // Device or PV to check.
-	Dump             string   `arg:"dump"`             // Which header or metadata to dump.
-	File             string   `arg:"file"`             // Metadata file to read or write.
-	Repair           bool     `arg:"repair"`           // Repair headers and metadata.
-	RepairType       string   `arg:"repairtype"`       // Repair type.
-	LabelSector      *int     `arg:"labelsector"`      // Sector for the LVM2 identifier.
-	PVMetadataCopies *int     `arg:"pvmetadatacopies"` // Number of metadata areas on a PV.
-	Settings         []string `arg:"settings"`         // Command specific settings in `key=value` format.
+	Name             string   `arg:"0" json:"name,omitempty"`                            // Device or PV to check.
+	Dump             string   `arg:"dump" json:"dump,omitempty"`                         // Which header or metadata to dump.
+	File             string   `arg:"file" json:"file,omitempty"`                         // Metadata file to read or write.
+	Repair           bool     `arg:"repair" json:"repair,omitempty"`                     // Repair headers and metadata.
+	RepairType       string   `arg:"repairtype" json:"repairType,omitempty"`             // Repair type.
+	LabelSector      *int     `arg:"labelsector" json:"labelSector,omitempty"`           // Sector for the LVM2 identifier.
+	PVMetadataCopies *int     `arg:"pvmetadatacopies" json:"pvMetadataCopies,omitempty"` // Number of metadata areas on a PV.
+	Settings         []string `arg:"settings" json:"settings,omitempty"`                 // Command specific settings in `key=value` format.
 }
 
 // MovePEOptions provides options for moving PVs (pvmove).
 type MovePEOptions struct {
 	CommonOptions
-	Source      string   `arg:"0"`          // Device or PV to move.
-	Destination []string `arg:"1"`          // Device or PV to move to.
-	LVName      string   `arg:"name"`       // Move only the extents belonging to the LV.
-	AutoBackup  *YesNo   `arg:"autobackup"` // Auto backup metadata after changes.
-	Alloc       string   `arg:"alloc"`      // Allocation policy for Physical Extents.
-	Abort       bool     `arg:"abort"`      // Abort any PV move operations in progress.
-	Atomic      bool     `arg:"atomic"`     // Atomic migration with mirrored temp LV; if interrupted, data remains on source PV.
-	Background  bool     `arg:"background"` // Move extents in the background.
-	Interval    *int     `arg:"interval"`   // Report progress at regular intervals.
-	NoUdevSync  bool     `arg:"noudevsync"` // Allow operations to proceed without waiting for udev notifications.
+	Source        string   `arg:"0" json:"source,omitempty"`              // Device or PV to move.
+	Destination   []string `arg:"1" json:"destination,omitempty"`         // Device or PV to move to.
+	LVName        string   `arg:"name" json:"lvName,omitempty"`           // Move only the extents belonging to the LV.
+	AutoBackup    *YesNo   `arg:"autobackup" json:"autoBackup,omitempty"` // Auto backup metadata after changes.
+	Alloc         string   `arg:"alloc" json:"alloc,omitempty"`           // Allocation policy for Physical Extents.
+	Abort         bool     `arg:"abort" json:"abort,omitempty"`           // Abort any PV move operations in progress.
+	Atomic        bool     `arg:"atomic" json:"atomic,omitempty"`         // Atomic migration with mirrored temp LV; if interrupted, data remains on source PV.
+	Background    bool     `arg:"background" json:"background,omitempty"` // Move extents in the background.
+	Interval      *int     `arg:"interval" json:"interval,omitempty"`     // Report progress at regular intervals.
+	NoUdevSync    bool     `arg:"noudevsync" json:"noUdevSync,omitempty"` // Allow operations to proceed without waiting for udev notifications.
+	SourceExtents string   `json:"sourceExtents,omitempty"`               // Range of extents on the source PV to move, eg. "1000-1999".
+}
+
+// withSourceExtents appends the extent range to the source PV, as pvmove
+// expects it in the form /dev/sdb:1000-1999.
+func (opts MovePEOptions) withSourceExtents() MovePEOptions {
+	if opts.SourceExtents != "" {
+		opts.Source += ":" + opts.SourceExtents
+	}
+
+	return opts
+}
+
+// MoveStatus describes the progress of a physical extent move (pvmove).
+type MoveStatus struct {
+	InProgress bool    // Set if a move from the PV is in progress.
+	LVName     string  // Name of the temporary LV used for the move.
+	Percent    float64 // Percentage of the extents that have been moved.
 }
 
 // ResizePVOptions provides options for resizing PVs (pvresize).
 type ResizePVOptions struct {
 	CommonOptions
-	Name                  string `arg:"0"`                     // Device or PV to resize.
-	SetPhysicalVolumeSize string `arg:"setphysicalvolumesize"` // Manually set the PV size.
+	Name                  string `arg:"0" json:"name,omitempty"`                                      // Device or PV to resize.
+	SetPhysicalVolumeSize string `arg:"setphysicalvolumesize" json:"setPhysicalVolumeSize,omitempty"` // Manually set the PV size.
+	Force                 bool   `json:"force,omitempty"`                                             // Skip checking that a smaller size leaves room for the allocated extents.
+}
+
+// ScanPVOptions provides options for scanning PVs (pvscan).
+type ScanPVOptions struct {
+	CommonOptions
+	Device         string     `arg:"0" json:"device,omitempty"`                      // Scan only this device.
+	Cache          bool       `arg:"cache" json:"cache,omitempty"`                   // Record PVs online for autoactivation.
+	Activate       Activation `arg:"activate" json:"activate,omitempty"`             // Autoactivate complete VGs, only ActivateAuto is accepted.
+	AutoActivation string     `arg:"autoactivation" json:"autoActivation,omitempty"` // The kind of autoactivation being performed, eg. `event`.
+	CheckComplete  bool       `arg:"checkcomplete" json:"checkComplete,omitempty"`   // Check if all the PVs needed by the VG are online.
+	VGOnline       bool       `arg:"vgonline" json:"vgOnline,omitempty"`             // Check if the VG has already been autoactivated.
+	NoUdevSync     bool       `arg:"noudevsync" json:"noUdevSync,omitempty"`         // Ignore udev notifications.
+}
+
+// Device represents a block device visible to LVM2.
+type Device struct {
+	Path string // Path to the device.
+	Size string // Size of the device in current units.
+	IsPV bool   // Set if the device has been initialized as a PV.
+}
+
+// AddDeviceOptions provides options for adding a device to the devices file
+// (lvmdevices --adddev).
+type AddDeviceOptions struct {
+	CommonOptions
+	Name         string `arg:"adddev" json:"name,omitempty"`               // Path of the device to add.
+	DeviceIDType string `arg:"deviceidtype" json:"deviceIDType,omitempty"` // Type of device ID to record, eg. `wwid` or `devname`.
+}
+
+// RemoveDeviceOptions provides options for removing a device from the devices
+// file (lvmdevices --deldev).
+type RemoveDeviceOptions struct {
+	CommonOptions
+	Name string `arg:"deldev" json:"name,omitempty"`  // Path of the device to remove.
+	PVID string `arg:"delpvid" json:"pvid,omitempty"` // PV UUID of the device to remove, eg. if the device is missing.
+}
+
+// ConfiguredDevice is an entry in the devices file.
+type ConfiguredDevice struct {
+	IDType  string // Type of the device ID, eg. `sys_wwid` or `devname`.
+	IDName  string // Device ID, eg. the WWID.
+	DevName string // Last known path of the device.
+	PVID    string // PV UUID of the device, or empty if it isn't a PV.
+}
+
+// CheckDevicesOptions provides options for checking the entries in the
+// devices file (lvmdevices --check).
+type CheckDevicesOptions struct {
+	CommonOptions
+	RefreshDevices bool `arg:"refresh" json:"refreshDevices,omitempty"` // Search for missing devices by their new names or IDs.
 }
 
 // VolumeGroup represents an LVM2 Volume Group (VG).
@@ -239,580 +462,807 @@ type VolumeGroup struct {
 	MetadataFree       string     `json:"vg_mda_free"`          // Free metadata area space for this VG in current units.
 	MetadataSize       string     `json:"vg_mda_size"`          // Size of smallest metadata area for this VG in current units.
 	MetadataCopies     string     `json:"vg_mda_copies"`        // Target number of in use metadata areas in the VG.
+	// Set if the VG has a pool metadata spare LV, which is used to repair thin
+	// and cache pools. This isn't a vgs field, so it is filled in from the LVs
+	// by ListVolumeGroups and FullReport. ListVolumeGroups looks the LVs up
+	// separately, and leaves it unset if that fails.
+	PoolMetadataSpare bool `json:"-"`
 }
 
 // ListVGOptions provides options for listing VGs (vgs).
 type ListVGOptions struct {
 	CommonOptions
-	Names                []string `arg:"0"`                    // Specific VGs to display.
-	Select               string   `arg:"select"`               // Filters objects based on criteria.
-	Foreign              bool     `arg:"foreign"`              // Lists foreign VGs.
-	IgnoreLockingFailure bool     `arg:"ignorelockingfailure"` // Whether to proceed in read-only mode after lock failures.
-	ReadOnly             bool     `arg:"readonly"`             // Read metadata without locks.
-	Shared               bool     `arg:"shared"`               // Displays shared VGs without active lvmlockd.
+	Names                []string `arg:"0" json:"names,omitempty"`                                   // Specific VGs to display.
+	Select               string   `arg:"select" json:"select,omitempty"`                             // Filters objects based on criteria, see Select.
+	Foreign              bool     `arg:"foreign" json:"foreign,omitempty"`                           // Lists foreign VGs.
+	IgnoreLockingFailure bool     `arg:"ignorelockingfailure" json:"ignoreLockingFailure,omitempty"` // Whether to proceed in read-only mode after lock failures.
+	ReadOnly             bool     `arg:"readonly" json:"readOnly,omitempty"`                         // Read metadata without locks.
+	Shared               bool     `arg:"shared" json:"shared,omitempty"`                             // Displays shared VGs without active lvmlockd.
+	Options              []string `arg:"options" json:"options,omitempty"`                           // Additional fields to report, alongside the default fields.
 }
 
 // CreateVGOptions provides options for creating VGs (vgcreate).
 type CreateVGOptions struct {
 	CommonOptions
-	Name                string   `arg:"0"`                   // Name of the VG to create.
-	PVNames             []string `arg:"1"`                   // List of PVs to add to the VG.
-	Force               bool     `arg:"force"`               // Overrides checks and protections.
-	AutoBackup          *YesNo   `arg:"autobackup"`          // Auto backup metadata after changes.
-	MaxLogicalVolumes   *int     `arg:"maxlogicalvolumes"`   // Max number of LVs allowed in a VG.
-	MaxPhysicalVolumes  *int     `arg:"maxphysicalvolumes"`  // Max number of PVs that can belong to the VG.
-	PhysicalExtentSize  string   `arg:"physicalextentsize"`  // Extent size of PVs in the group.
-	Zero                *YesNo   `arg:"zero"`                // Wipe first 4 sectors of the device.
-	Tags                []string `arg:"addtag"`              // Tags to add to the VG.
-	Alloc               string   `arg:"alloc"`               // Allocation policy for Physical Extents.
-	LabelSector         *int     `arg:"labelsector"`         // Sector for the LVM2 identifier.
-	MetadataSize        string   `arg:"metadatasize"`        // Space for each VG metadata area.
-	PVMetadataCopies    *int     `arg:"pvmetadatacopies"`    // Number of metadata areas on a PV.
-	VGMetadataCopies    string   `arg:"vgmetadatacopies"`    // Number of copies of VG metadata.
-	DataAlignment       string   `arg:"dataalignment"`       // Align PV data's start, may be shifted by DataAlignmentOffset.
-	DataAlignmentOffset string   `arg:"dataalignmentoffset"` // Additional shift for PV data's start.
-	Shared              bool     `arg:"shared"`              // If set, VG is shared across multiple hosts using lvmlockd.
-	SystemID            string   `arg:"systemid"`            // Specific system ID for the new VG.
-	LockType            string   `arg:"locktype"`            // Directly specifies the VG lock type.
-	SetAutoActivation   *YesNo   `arg:"setautoactivation"`   // Enable autoactivation for the VG.
+	Name                string   `arg:"0" json:"name,omitempty"`                                  // Name of the VG to create.
+	PVNames             []string `arg:"1" json:"pvNames,omitempty"`                               // List of PVs to add to the VG.
+	Force               bool     `arg:"force" json:"force,omitempty"`                             // Overrides checks and protections.
+	AutoBackup          *YesNo   `arg:"autobackup" json:"autoBackup,omitempty"`                   // Auto backup metadata after changes.
+	MaxLogicalVolumes   *int     `arg:"maxlogicalvolumes" json:"maxLogicalVolumes,omitempty"`     // Max number of LVs allowed in a VG.
+	MaxPhysicalVolumes  *int     `arg:"maxphysicalvolumes" json:"maxPhysicalVolumes,omitempty"`   // Max number of PVs that can belong to the VG.
+	PhysicalExtentSize  string   `arg:"physicalextentsize" json:"physicalExtentSize,omitempty"`   // Extent size of PVs in the group.
+	Zero                *YesNo   `arg:"zero" json:"zero,omitempty"`                               // Wipe first 4 sectors of the device.
+	Tags                []string `arg:"addtag" json:"tags,omitempty"`                             // Tags to add to the VG.
+	Alloc               string   `arg:"alloc" json:"alloc,omitempty"`                             // Allocation policy for Physical Extents.
+	LabelSector         *int     `arg:"labelsector" json:"labelSector,omitempty"`                 // Sector for the LVM2 identifier.
+	MetadataSize        string   `arg:"metadatasize" json:"metadataSize,omitempty"`               // Space for each VG metadata area.
+	PVMetadataCopies    *int     `arg:"pvmetadatacopies" json:"pvMetadataCopies,omitempty"`       // Number of metadata areas on a PV.
+	VGMetadataCopies    string   `arg:"vgmetadatacopies" json:"vgMetadataCopies,omitempty"`       // Number of copies of VG metadata.
+	DataAlignment       string   `arg:"dataalignment" json:"dataAlignment,omitempty"`             // Align PV data's start, may be shifted by DataAlignmentOffset.
+	DataAlignmentOffset string   `arg:"dataalignmentoffset" json:"dataAlignmentOffset,omitempty"` // Additional shift for PV data's start.
+	Shared              bool     `arg:"shared" json:"shared,omitempty"`                           // If set, VG is shared across multiple hosts using lvmlockd.
+	SystemID            string   `arg:"systemid" json:"systemID,omitempty"`                       // Specific system ID for the new VG.
+	LockType            string   `arg:"locktype" json:"lockType,omitempty"`                       // Directly specifies the VG lock type.
+	SetAutoActivation   *YesNo   `arg:"setautoactivation" json:"setAutoActivation,omitempty"`     // Enable autoactivation for the VG.
 }
 
 // UpdateVGOptions provides options for modifying VGs (vgchange).
 type UpdateVGOptions struct {
 	CommonOptions
-	Name                 string   `arg:"0"`                    // Name of the VG to modify.
-	MaxLogicalVolumes    *int     `arg:"logicalvolume"`        // Max number of LVs allowed in a VG.
-	MaxPhysicalVolumes   *int     `arg:"maxphysicalvolumes"`   // Max number of PVs that can belong to the VG.
-	UUID                 bool     `arg:"uuid"`                 // Generate a new UUID for the VG.
-	PhysicalExtentSize   string   `arg:"physicalextentsize"`   // Extent size of PVs in the group.
-	Resizeable           *YesNo   `arg:"resizeable"`           // Toggle whether PVs can be added or removed.
-	AddTags              []string `arg:"addtag"`               // Add tag/s to the VG.
-	DelTags              []string `arg:"deltag"`               // Remove tag/s from the VG.
-	Alloc                string   `arg:"alloc"`                // Allocation policy for Physical Extents.
-	PVMetadataCopies     *int     `arg:"pvmetadatacopies"`     // Number of metadata areas on a PV.
-	VGMetadataCopies     string   `arg:"vgmetadatacopies"`     // Number of copies of VG metadata.
-	DetachProfile        bool     `arg:"detachprofile"`        // Detach a metadata profile.
-	SetAutoActivation    *YesNo   `arg:"setautoactivation"`    // Enable autoactivation for the VG.
-	AutoBackup           *YesNo   `arg:"autobackup"`           // Auto backup metadata after changes.
-	Select               string   `arg:"select"`               // Filters objects based on criteria.
-	Force                bool     `arg:"force"`                // Overrides checks and protections.
-	Poll                 *YesNo   `arg:"poll"`                 // Resume background operations that were halted due to disruptions.
-	IgnoreMonitoring     bool     `arg:"ignoremonitoring"`     // Ignore dmeventd monitoring.
-	NoUdevSync           bool     `arg:"noudevsync"`           // Ignore udev notifications.
-	Monitor              *YesNo   `arg:"monitor"`              // Toggle monitoring by dmeventd.
-	Refresh              bool     `arg:"refresh"`              // Refreshes the VG metadata.
-	Activate             *YesNo   `arg:"activate"`             // Activate the VG.
-	IgnoreActivationSkip bool     `arg:"ignoreactivationskip"` // Ignore the "activation skip" flag.
-	Partial              bool     `arg:"partial"`              // Attempt activation with missing Physical Extents.
-	ActivationMode       string   `arg:"activationmode"`       // Conditions under which a LV can be activated with missing PVs.
-	AutoActivation       string   `arg:"autoactivation"`       // Activation should occur automatically in response to specific events.
-	LockType             string   `arg:"locktype"`             // Directly specifies the VG lock type.
-	LockStart            bool     `arg:"lockstart"`            // Start the lockspace of a shared VG in lvmlockd.
-	LockStop             bool     `arg:"lockstop"`             // Stop the lockspace of a shared VG in lvmlockd.
-	SysInit              bool     `arg:"sysinit"`              // Indicates that the command is being invoked from early system init scripts.
-	IgnoreLockingFailure bool     `arg:"ignorelockingfailure"` // Whether to proceed in read-only mode after lock failures.
-	ReadOnly             bool     `arg:"readonly"`             // Read metadata without locks.
-	SystemID             string   `arg:"systemid"`             // Changes the system ID of the VG.
+	Name                 string     `arg:"0" json:"name,omitempty"`                                    // Name of the VG to modify.
+	MaxLogicalVolumes    *int       `arg:"logicalvolume" json:"maxLogicalVolumes,omitempty"`           // Max number of LVs allowed in a VG.
+	MaxPhysicalVolumes   *int       `arg:"maxphysicalvolumes" json:"maxPhysicalVolumes,omitempty"`     // Max number of PVs that can belong to the VG.
+	UUID                 bool       `arg:"uuid" json:"uuid,omitempty"`                                 // Generate a new UUID for the VG.
+	PhysicalExtentSize   string     `arg:"physicalextentsize" json:"physicalExtentSize,omitempty"`     // Extent size of PVs in the group.
+	Resizeable           *YesNo     `arg:"resizeable" json:"resizeable,omitempty"`                     // Toggle whether PVs can be added or removed.
+	AddTags              []string   `arg:"addtag" json:"addTags,omitempty"`                            // Add tag/s to the VG.
+	DelTags              []string   `arg:"deltag" json:"delTags,omitempty"`                            // Remove tag/s from the VG.
+	Alloc                string     `arg:"alloc" json:"alloc,omitempty"`                               // Allocation policy for Physical Extents.
+	PVMetadataCopies     *int       `arg:"pvmetadatacopies" json:"pvMetadataCopies,omitempty"`         // Number of metadata areas on a PV.
+	VGMetadataCopies     string     `arg:"vgmetadatacopies" json:"vgMetadataCopies,omitempty"`         // Number of copies of VG metadata.
+	MetadataProfile      string     `arg:"metadataprofile" json:"metadataProfile,omitempty"`           // Attach a metadata profile.
+	DetachProfile        bool       `arg:"detachprofile" json:"detachProfile,omitempty"`               // Detach a metadata profile.
+	SetAutoActivation    *YesNo     `arg:"setautoactivation" json:"setAutoActivation,omitempty"`       // Enable autoactivation for the VG.
+	AutoBackup           *YesNo     `arg:"autobackup" json:"autoBackup,omitempty"`                     // Auto backup metadata after changes.
+	Select               string     `arg:"select" json:"select,omitempty"`                             // Filters objects based on criteria, see Select.
+	Force                bool       `arg:"force" json:"force,omitempty"`                               // Overrides checks and protections.
+	Poll                 *YesNo     `arg:"poll" json:"poll,omitempty"`                                 // Resume background operations that were halted due to disruptions.
+	IgnoreMonitoring     bool       `arg:"ignoremonitoring" json:"ignoreMonitoring,omitempty"`         // Ignore dmeventd monitoring.
+	NoUdevSync           bool       `arg:"noudevsync" json:"noUdevSync,omitempty"`                     // Ignore udev notifications.
+	Monitor              *YesNo     `arg:"monitor" json:"monitor,omitempty"`                           // Toggle monitoring by dmeventd.
+	Refresh              bool       `arg:"refresh" json:"refresh,omitempty"`                           // Refreshes the VG metadata.
+	Activate             Activation `arg:"activate" json:"activate,omitempty"`                         // Activate the VG.
+	IgnoreActivationSkip bool       `arg:"ignoreactivationskip" json:"ignoreActivationSkip,omitempty"` // Ignore the "activation skip" flag.
+	Partial              bool       `arg:"partial" json:"partial,omitempty"`                           // Attempt activation with missing Physical Extents.
+	IgnoreSkippedCluster bool       `arg:"ignoreskippedcluster" json:"ignoreSkippedCluster,omitempty"` // Don't fail if clustered VGs are skipped.
+	ActivationMode       string     `arg:"activationmode" json:"activationMode,omitempty"`             // Conditions under which a LV can be activated with missing PVs.
+	AutoActivation       string     `arg:"autoactivation" json:"autoActivation,omitempty"`             // Activation should occur automatically in response to specific events.
+	LockType             string     `arg:"locktype" json:"lockType,omitempty"`                         // Directly specifies the VG lock type.
+	LockStart            bool       `arg:"lockstart" json:"lockStart,omitempty"`                       // Start the lockspace of a shared VG in lvmlockd.
+	LockStop             bool       `arg:"lockstop" json:"lockStop,omitempty"`                         // Stop the lockspace of a shared VG in lvmlockd.
+	SysInit              bool       `arg:"sysinit" json:"sysInit,omitempty"`                           // Indicates that the command is being invoked from early system init scripts.
+	IgnoreLockingFailure bool       `arg:"ignorelockingfailure" json:"ignoreLockingFailure,omitempty"` // Whether to proceed in read-only mode after lock failures.
+	ReadOnly             bool       `arg:"readonly" json:"readOnly,omitempty"`                         // Read metadata without locks.
+	SystemID             *string    `arg:"systemid" json:"systemID,omitempty"`                         // Changes the system ID of the VG, an empty string clears it.
 }
 
 // RemoveVGOptions are options for removing VGs (vgremove).
 type RemoveVGOptions struct {
 	CommonOptions
-	Name       string `arg:"0"`          // Name of the VG to remove.
-	Force      bool   `arg:"force"`      // Overrides checks and protections.
-	Select     string `arg:"select"`     // Filters objects based on criteria.
-	NoUdevSync bool   `arg:"noudevsync"` // Allow operations to proceed without waiting for udev notifications.
+	Name       string `arg:"0" json:"name,omitempty"`                // Name of the VG to remove.
+	Force      bool   `arg:"force" json:"force,omitempty"`           // Overrides checks and protections.
+	Select     string `arg:"select" json:"select,omitempty"`         // Filters objects based on criteria, see Select.
+	NoUdevSync bool   `arg:"noudevsync" json:"noUdevSync,omitempty"` // Allow operations to proceed without waiting for udev notifications.
 }
 
 // CheckVGOptions provides options for checking VGs (vgck).
 type CheckVGOptions struct {
 	CommonOptions
-	Name           string `arg:"0"`              // Name of the VG to check.
-	UpdateMetadata bool   `arg:"updatemetadata"` // Correct VG metadata inconsistencies.
+	Name           string `arg:"0" json:"name,omitempty"`                        // Name of the VG to check.
+	UpdateMetadata bool   `arg:"updatemetadata" json:"updateMetadata,omitempty"` // Correct VG metadata inconsistencies.
 }
 
 // ExportVGOptions provides options for exporting VGs (vgexport).
 type ExportVGOptions struct {
 	CommonOptions
-	Name   string `arg:"0"`      // Name of the VG to export.
-	Select string `arg:"select"` // Filters objects based on criteria.
-	All    bool   `arg:"all"`    // Export all VGs.
+	Name   string `arg:"0" json:"name,omitempty"`        // Name of the VG to export.
+	Select string `arg:"select" json:"select,omitempty"` // Filters objects based on criteria, see Select.
+	All    bool   `arg:"all" json:"all,omitempty"`       // Export all VGs.
 }
 
 // ImportVGOptions provides options for importing VGs (vgimport).
 type ImportVGOptions struct {
 	CommonOptions
-	Name   string `arg:"0"`      // Name of the VG to import.
-	Select string `arg:"select"` // Filters objects based on criteria.
-	All    bool   `arg:"all"`    // Import all VGs.
-	Force  bool   `arg:"force"`  // Overrides checks and protections.
+	Name   string `arg:"0" json:"name,omitempty"`        // Name of the VG to import.
+	Select string `arg:"select" json:"select,omitempty"` // Filters objects based on criteria, see Select.
+	All    bool   `arg:"all" json:"all,omitempty"`       // Import all VGs.
+	Force  bool   `arg:"force" json:"force,omitempty"`   // Overrides checks and protections.
 }
 
 // ImportVGFromClonedOptions provides options for importing VGs from cloned PVs (vgimportclone).
 type ImportVGFromClonedOptions struct {
 	CommonOptions
-	Name          string   `arg:"name"`          // Name of the VG to import.
-	PVNames       []string `arg:"0"`             // List of PVs to import from.
-	Import        bool     `arg:"import"`        // Import exported VGs.
-	ImportDevices bool     `arg:"importdevices"` // Add devices to the devices file.
+	Name          string   `arg:"name" json:"name,omitempty"`                   // Name of the VG to import.
+	PVNames       []string `arg:"0" json:"pvNames,omitempty"`                   // List of PVs to import from.
+	Import        bool     `arg:"import" json:"import,omitempty"`               // Import exported VGs.
+	ImportDevices bool     `arg:"importdevices" json:"importDevices,omitempty"` // Add devices to the devices file.
 }
 
 // MergeVGOptions provides options for merging VGs (vgmerge).
 type MergeVGOptions struct {
 	CommonOptions
-	Destination       string `arg:"0"`                 // Name of the VG to merge into.
-	Source            string `arg:"1"`                 // Name of the VG to merge.
-	AutoBackup        *YesNo `arg:"autobackup"`        // Auto backup metadata after changes.
-	PoolMetadataSpare *YesNo `arg:"poolmetadataspare"` // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
+	Destination       string `arg:"0" json:"destination,omitempty"`                       // Name of the VG to merge into.
+	Source            string `arg:"1" json:"source,omitempty"`                            // Name of the VG to merge.
+	AutoBackup        *YesNo `arg:"autobackup" json:"autoBackup,omitempty"`               // Auto backup metadata after changes.
+	PoolMetadataSpare *YesNo `arg:"poolmetadataspare" json:"poolMetadataSpare,omitempty"` // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
 }
 
 // ExtendVGOptions provides options for extending VGs (vgextend).
 type ExtendVGOptions struct {
 	CommonOptions
-	Name                string   `arg:"0"`                   // Name of the VG to extend.
-	PVNames             []string `arg:"1"`                   // List of PVs to add to the VG.
-	AutoBackup          *YesNo   `arg:"autobackup"`          // Auto backup metadata after changes.
-	Force               bool     `arg:"force"`               // Override checks and protections.
-	Zero                *YesNo   `arg:"zero"`                // Wipe first 4 sectors of the device.
-	LabelSector         *int     `arg:"labelsector"`         // Sector for the LVM2 identifier.
-	MetadataSize        string   `arg:"metadatasize"`        // Space for each VG metadata area.
-	PVMetadataCopies    *int     `arg:"pvmetadatacopies"`    // Number of metadata areas on a PV.
-	MetadataIgnore      *YesNo   `arg:"metadataignore"`      // If set, metadata won't be stored on the PV.
-	DataAlignment       string   `arg:"dataalignment"`       // Align PV data's start, may be shifted by DataAlignmentOffset.
-	DataAlignmentOffset string   `arg:"dataalignmentoffset"` // Additional shift for PV data's start.
-	RestoreMissing      bool     `arg:"restoremissing"`      // Add a PV back into a VG after the PV was missing and then returned.
+	Name                string   `arg:"0" json:"name,omitempty"`                                  // Name of the VG to extend.
+	PVNames             []string `arg:"1" json:"pvNames,omitempty"`                               // List of PVs to add to the VG.
+	AutoBackup          *YesNo   `arg:"autobackup" json:"autoBackup,omitempty"`                   // Auto backup metadata after changes.
+	Force               bool     `arg:"force" json:"force,omitempty"`                             // Override checks and protections.
+	Zero                *YesNo   `arg:"zero" json:"zero,omitempty"`                               // Wipe first 4 sectors of the device.
+	LabelSector         *int     `arg:"labelsector" json:"labelSector,omitempty"`                 // Sector for the LVM2 identifier.
+	MetadataSize        string   `arg:"metadatasize" json:"metadataSize,omitempty"`               // Space for each VG metadata area.
+	PVMetadataCopies    *int     `arg:"pvmetadatacopies" json:"pvMetadataCopies,omitempty"`       // Number of metadata areas on a PV.
+	MetadataIgnore      *YesNo   `arg:"metadataignore" json:"metadataIgnore,omitempty"`           // If set, metadata won't be stored on the PV.
+	DataAlignment       string   `arg:"dataalignment" json:"dataAlignment,omitempty"`             // Align PV data's start, may be shifted by DataAlignmentOffset.
+	DataAlignmentOffset string   `arg:"dataalignmentoffset" json:"dataAlignmentOffset,omitempty"` // Additional shift for PV data's start.
+	RestoreMissing      bool     `arg:"restoremissing" json:"restoreMissing,omitempty"`           // Add a PV back into a VG after the PV was missing and then returned.
 }
 
 // ReduceVGOptions provides options for reducing VGs (vgreduce).
 type ReduceVGOptions struct {
 	CommonOptions
-	Name          string   `arg:"0"`             // Name of the VG to reduce.
-	PVNames       []string `arg:"1"`             // List of PVs to remove from the VG.
-	All           bool     `arg:"all"`           // Remove all unused PVs from the VG.
-	RemoveMissing bool     `arg:"removemissing"` // Remove missing PVs from the VG.
-	MirrorsOnly   bool     `arg:"mirrorsonly"`   // Only remove missing PVs from mirror LVs.
-	AutoBackup    *YesNo   `arg:"autobackup"`    // Auto backup metadata after changes.
-	Force         bool     `arg:"force"`         // Override checks and protections.
+	Name          string   `arg:"0" json:"name,omitempty"`                      // Name of the VG to reduce.
+	PVNames       []string `arg:"1" json:"pvNames,omitempty"`                   // List of PVs to remove from the VG.
+	All           bool     `arg:"all" json:"all,omitempty"`                     // Remove all unused PVs from the VG.
+	RemoveMissing bool     `arg:"removemissing" json:"removeMissing,omitempty"` // Remove missing PVs from the VG.
+	MirrorsOnly   bool     `arg:"mirrorsonly" json:"mirrorsOnly,omitempty"`     // Only remove missing PVs from mirror LVs.
+	AutoBackup    *YesNo   `arg:"autobackup" json:"autoBackup,omitempty"`       // Auto backup metadata after changes.
+	Force         bool     `arg:"force" json:"force,omitempty"`                 // Override checks and protections.
 }
 
 // RenameVGOptions provides options for renaming VGs (vgrename).
 type RenameVGOptions struct {
 	CommonOptions
-	From       string `arg:"0"`          // Name of the VG to rename.
-	To         string `arg:"1"`          // New name for the VG.
-	AutoBackup *YesNo `arg:"autobackup"` // Auto backup metadata after changes.
-	Force      bool   `arg:"force"`      // Override checks and protections.
+	From       string `arg:"0" json:"from,omitempty"`                // Name of the VG to rename.
+	To         string `arg:"1" json:"to,omitempty"`                  // New name for the VG.
+	AutoBackup *YesNo `arg:"autobackup" json:"autoBackup,omitempty"` // Auto backup metadata after changes.
+	Force      bool   `arg:"force" json:"force,omitempty"`           // Override checks and protections.
 }
 
 // MovePVOptions provides options for moving PVs between VGs (vgsplit).
 type MovePVOptions struct {
 	CommonOptions
-	Source             string   `arg:"0"`                  // Name of the VG to move PVs from.
-	Destination        string   `arg:"1"`                  // Name of the VG to move PVs to.
-	PVNames            []string `arg:"2"`                  // List of PVs to move.
-	LVName             string   `arg:"name"`               // Move only PVs used by the LV.
-	AutoBackup         *YesNo   `arg:"autobackup"`         // Auto backup metadata after changes.
-	MaxLogicalVolumes  *int     `arg:"maxlogicalvolumes"`  // Max number of LVs allowed in a VG.
-	MaxPhysicalVolumes *int     `arg:"maxphysicalvolumes"` // Max number of PVs that can belong to the VG.
-	Alloc              string   `arg:"alloc"`              // Allocation policy for Physical Extents.
-	PoolMetadataSpare  *YesNo   `arg:"poolmetadataspare"`  // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
-	VGMetadataCopies   string   `arg:"vgmetadatacopies"`   // Number of copies of VG metadata.
+	Source             string   `arg:"0" json:"source,omitempty"`                              // Name of the VG to move PVs from.
+	Destination        string   `arg:"1" json:"destination,omitempty"`                         // Name of the VG to move PVs to.
+	PVNames            []string `arg:"2" json:"pvNames,omitempty"`                             // List of PVs to move.
+	LVName             string   `arg:"name" json:"lvName,omitempty"`                           // Move only PVs used by the LV.
+	AutoBackup         *YesNo   `arg:"autobackup" json:"autoBackup,omitempty"`                 // Auto backup metadata after changes.
+	MaxLogicalVolumes  *int     `arg:"maxlogicalvolumes" json:"maxLogicalVolumes,omitempty"`   // Max number of LVs allowed in a VG.
+	MaxPhysicalVolumes *int     `arg:"maxphysicalvolumes" json:"maxPhysicalVolumes,omitempty"` // Max number of PVs that can belong to the VG.
+	Alloc              string   `arg:"alloc" json:"alloc,omitempty"`                           // Allocation policy for Physical Extents.
+	PoolMetadataSpare  *YesNo   `arg:"poolmetadataspare" json:"poolMetadataSpare,omitempty"`   // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
+	VGMetadataCopies   string   `arg:"vgmetadatacopies" json:"vgMetadataCopies,omitempty"`     // Number of copies of VG metadata.
 }
 
 // MakeVGDeviceNodesOptions provides options for creating LV device nodes for a VG (vgmknodes).
 type MakeVGDeviceNodesOptions struct {
 	CommonOptions
-	Name                 string `arg:"0"`                    // Name of the VG.
-	IgnoreLockingFailure bool   `arg:"ignorelockingfailure"` // Whether to proceed in read-only mode after lock failures.
-	Refresh              bool   `arg:"refresh"`              // Refreshes the VG metadata.
+	Name                 string `arg:"0" json:"name,omitempty"`                                    // Name of the VG.
+	IgnoreLockingFailure bool   `arg:"ignorelockingfailure" json:"ignoreLockingFailure,omitempty"` // Whether to proceed in read-only mode after lock failures.
+	Refresh              bool   `arg:"refresh" json:"refresh,omitempty"`                           // Refreshes the VG metadata.
 }
 
 // LogicalVolume represents an LVM2 Logical Volume (LV).
 type LogicalVolume struct {
-	UUID                               string     `json:"lv_uuid"`                     // Unique identifier.
-	Name                               string     `json:"lv_name"`                     // Name.  LVs created for internal use are enclosed in brackets.
-	FullName                           string     `json:"lv_full_name"`                // Full name of LV including its VG, namely VG/LV.
-	Path                               string     `json:"lv_path"`                     // Full pathname for LV. Blank for internal LVs.
-	DMPath                             string     `json:"lv_dm_path"`                  // Internal device//mapper pathname for LV (in /dev/mapper directory).
-	Parent                             string     `json:"lv_parent"`                   // For LVs that are components of another LV, the parent LV.
-	VGName                             string     `json:"vg_name"`                     // Name of the VG the LV belongs to.
-	Layout                             string     `json:"lv_layout"`                   // LV layout.
-	Role                               string     `json:"lv_role"`                     // LV role.
-	InitialImageSync                   BoolString `json:"lv_initial_image_sync"`       // Set if mirror/RAID images underwent initial resynchronization.
-	ImageSynced                        BoolString `json:"lv_image_synced"`             // Set if mirror/RAID image is synchronized.
-	Merging                            BoolString `json:"lv_merging"`                  // Set if snapshot LV is being merged to origin.
-	Converting                         BoolString `json:"lv_converting"`               // Set if LV is being converted.
-	AllocationPolicy                   string     `json:"lv_allocation_policy"`        // LV allocation policy.
-	AllocationLocked                   BoolString `json:"lv_allocation_locked"`        // Set if LV is locked against allocation changes.
-	FixedMinor                         BoolString `json:"lv_fixed_minor"`              // Set if LV has fixed minor number assigned.
-	SkipActivation                     BoolString `json:"lv_skip_activation"`          // Set if LV is skipped on activation.
-	AutoActivation                     BoolString `json:"lv_autoactivation"`           // Set if LV autoactivation is enabled.
-	WhenFull                           string     `json:"lv_when_full"`                // For thin pools, behavior when full.
-	Active                             string     `json:"lv_active"`                   // Active state of the LV.
-	ActiveLocally                      BoolString `json:"lv_active_locally"`           // Set if the LV is active locally.
-	ActiveRemotely                     BoolString `json:"lv_active_remotely"`          // Set if the LV is active remotely.
-	ActiveExclusively                  BoolString `json:"lv_active_exclusively"`       // Set if the LV is active exclusively.
-	Major                              IntString  `json:"lv_major"`                    // Persistent major number or -1 if not persistent.
-	Minor                              IntString  `json:"lv_minor"`                    // Persistent minor number or -1 if not persistent.
-	ReadAhead                          string     `json:"lv_read_ahead"`               // Read ahead setting in current units.
-	Size                               string     `json:"lv_size"`                     // Size of LV in current units.
-	MetadataSize                       string     `json:"lv_metadata_size"`            // For thin and cache pools, the size of the LV that holds the metadata.
-	SegmentCount                       IntString  `json:"seg_count"`                   // Number of segments in LV.
-	Origin                             string     `json:"origin"`                      // For snapshots and thins, the origin device of this LV.
-	OriginUUID                         string     `json:"origin_uuid"`                 // For snapshots and thins, the UUID of origin device of this LV.
-	OriginSize                         string     `json:"origin_size"`                 // For snapshots, the size of the origin device of this LV.
-	Ancestors                          string     `json:"lv_ancestors"`                // LV ancestors ignoring any stored history of the ancestry chain.
-	FullAncestors                      string     `json:"lv_full_ancestors"`           // LV ancestors including stored history of the ancestry chain.
-	Descendants                        string     `json:"lv_descendants"`              // LV descendants ignoring any stored history of the ancestry chain.
-	FullDescendants                    string     `json:"lv_full_descendants"`         // LV descendants including stored history of the ancestry chain.
-	RAIDMismatchCount                  IntString  `json:"raid_mismatch_count"`         // For RAID, number of mismatches found or repaired.
-	RAIDSyncAction                     string     `json:"raid_sync_action"`            // For RAID, the current synchronization action being performed.
-	RAIDWriteBehind                    string     `json:"raid_write_behind"`           // For RAID1, the number of outstanding writes allowed to writemostly devices.
-	RAIDMinRecoveryRate                string     `json:"raid_min_recovery_rate"`      // For RAID1, the minimum recovery I/O load in kiB/sec/disk.
-	RAIDMaxRecoveryRate                string     `json:"raid_max_recovery_rate"`      // For RAID1, the maximum recovery I/O load in kiB/sec/disk.
-	RAIDIntegrityMode                  string     `json:"raidintegritymode"`           // The integrity mode
-	RAIDIntegrityBlockSize             string     `json:"raidintegrityblocksize"`      // The integrity block size
-	RAIDIntegrityMismatches            IntString  `json:"integritymismatches"`         // The number of integrity mismatches.
-	MovePV                             string     `json:"move_pv"`                     // For pvmove, Source PV of temporary LV created by pvmove.
-	MovePVUUID                         string     `json:"move_pv_uuid"`                // For pvmove, the UUID of Source PV of temporary LV created by pvmove.
-	ConvertLV                          string     `json:"convert_lv"`                  // For lvconvert, Name of temporary LV created by lvconvert.
-	ConvertLVUUID                      string     `json:"convert_lv_uuid"`             // For lvconvert, UUID of temporary LV created by lvconvert.
-	MirrorLog                          string     `json:"mirror_log"`                  // For mirrors, the LV holding the synchronisation log.
-	MirrorLogUUID                      string     `json:"mirror_log_uuid"`             // For mirrors, the UUID of the LV holding the synchronisation log.
-	DataLV                             string     `json:"data_lv"`                     // For cache/thin/vdo pools, the LV holding the associated data.
-	DataLVUUID                         string     `json:"data_lv_uuid"`                // For cache/thin/vdo pools, the UUID of the LV holding the associated data.
-	MetadataLV                         string     `json:"metadata_lv"`                 // For cache/thin pools, the LV holding the associated metadata.
-	MetadataLVUUID                     string     `json:"metadata_lv_uuid"`            // For cache/thin pools, the UUID of the LV holding the associated metadata.
-	PoolLV                             string     `json:"pool_lv"`                     // For cache/thin/vdo volumes, the cache/thin/vdo pool LV for this volume.
-	PoolLVUUID                         string     `json:"pool_lv_uuid"`                // For cache/thin/vdo volumes, the UUID of the cache/thin/vdo pool LV for this volume.
-	Tags                               string     `json:"lv_tags"`                     // Tags, if any.
-	Profile                            string     `json:"lv_profile"`                  // Configuration profile attached to this LV.
-	LockArgs                           string     `json:"lv_lockargs"`                 // Lock args of the LV used by lvmlockd.
-	CreationTime                       string     `json:"lv_time"`                     // Creation time of the LV, if known
-	RemovalTime                        string     `json:"lv_time_removed"`             // Removal time of the LV, if known
-	CreationHost                       string     `json:"lv_host"`                     // Creation host of the LV, if known.
-	RequiredModules                    string     `json:"lv_modules"`                  // Kernel device-mapper modules required for this LV.
-	Historical                         BoolString `json:"lv_historical"`               // Set if the LV is historical.
-	WriteCacheBlockSize                string     `json:"writecache_block_size"`       // The writecache block size
-	KernelMajor                        string     `json:"lv_kernel_major"`             // Currently assigned major number or -1 if LV is not active.
-	KernelMinor                        string     `json:"lv_kernel_minor"`             // Currently assigned minor number or -1 if LV is not active.
-	KernelReadAhead                    string     `json:"lv_kernel_read_ahead"`        // Currently-in-use read ahead setting in current units.
-	Attributes                         string     `json:"lv_attr"`                     // LV attributes.
-	Permissions                        BoolString `json:"lv_permissions"`              // LV permissions.
-	Suspended                          BoolString `json:"lv_suspended"`                // Set if LV is suspended.
-	LiveTable                          BoolString `json:"lv_live_table"`               // Set if LV has live table present.
-	InactiveTable                      BoolString `json:"lv_inactive_table"`           // Set if LV has inactive table present.
-	DeviceOpen                         BoolString `json:"lv_device_open"`              // Set if LV device is open.
-	DataPercent                        string     `json:"data_percent"`                // For snapshot, cache and thin pools and volumes, the percentage full if LV is active.
-	SnapshotPercent                    string     `json:"snap_percent"`                // For snapshots, the percentage full if LV is active.
-	MetadataPercent                    string     `json:"metadata_percent"`            // For cache and thin pools, the percentage of metadata full if LV is active.
-	CopyPercent                        string     `json:"copy_percent"`                // For Cache, RAID, mirrors and pvmove, current percentage in-sync.
-	SyncPercent                        string     `json:"sync_percent"`                // For Cache, RAID, mirrors and pvmove, current percentage in-sync.
-	CacheTotalBlocks                   IntString  `json:"cache_total_blocks"`          // Total cache blocks.
-	CacheUsedBlocks                    IntString  `json:"cache_used_blocks"`           // Used cache blocks.
-	CacheDirtyBlocks                   IntString  `json:"cache_dirty_blocks"`          // Dirty cache blocks.
-	CacheReadHits                      IntString  `json:"cache_read_hits"`             // Cache read hits.
-	CacheReadMisses                    IntString  `json:"cache_read_misses"`           // Cache read misses.
-	CacheWriteHits                     IntString  `json:"cache_write_hits"`            // Cache write hits.
-	CacheWriteMisses                   IntString  `json:"cache_write_misses"`          // Cache write misses.
-	KernelCacheSettings                string     `json:"kernel_cache_settings"`       // Cache settings/parameters as set in kernel, including default values (cached segments only).
-	KernelCachePolicy                  string     `json:"kernel_cache_policy"`         // Cache policy used in kernel.
-	KernelMetadataFormat               string     `json:"kernel_metadata_format"`      // Cache metadata format used in kernel.
-	HealthStatus                       string     `json:"lv_health_status"`            // LV health status.
-	KernelDiscards                     string     `json:"kernel_discards"`             // For thin pools, how discards are handled in kernel.
-	CheckNeeded                        string     `json:"lv_check_needed"`             // For thin pools and cache volumes, whether metadata check is needed.
-	MergeFailed                        BoolString `json:"lv_merge_failed"`             // Set if snapshot merge failed.
-	SnapshotInvalid                    BoolString `json:"lv_snapshot_invalid"`         // Set if snapshot LV is invalid.
-	VDOOperatingMode                   string     `json:"vdo_operating_mode"`          // For vdo pools, its current operating mode.
-	VDOCompressionState                string     `json:"vdo_compression_state"`       // For vdo pools, whether compression is running.
-	VDOIndexState                      string     `json:"vdo_index_state"`             // For vdo pools, state of index for deduplication.
-	VDOUsedSize                        string     `json:"vdo_used_size"`               // For vdo pools, currently used space.
-	VDOSavingPercent                   string     `json:"vdo_saving_percent"`          // For vdo pools, percentage of saved space.
-	WriteCacheTotalBlocks              IntString  `json:"writecache_total_blocks"`     // Total writecache blocks.
-	WriteCacheFreeBlocks               IntString  `json:"writecache_free_blocks"`      // Total writecache free blocks.
-	WriteCacheWritebackBlocks          IntString  `json:"writecache_writeback_blocks"` // Total writecache writeback blocks.
-	WriteCacheErrors                   IntString  `json:"writecache_error"`            // Total writecache errors.
-	Type                               string     `json:"segtype"`                     // Type of LV segment.
-	Stripes                            IntString  `json:"stripes"`                     // Number of stripes or mirror/raid1 legs.
-	DataStripes                        IntString  `json:"data_stripes"`                // Number of data stripes or mirror/raid1 legs.
-	ReshapeLength                      string     `json:"reshape_len"`                 // Size of out-of-place reshape space in current units.
-	ReshapeLengthExtents               string     `json:"reshape_len_le"`              // Size of out-of-place reshape space in logical extents.
-	DataCopies                         IntString  `json:"data_copies"`                 // Number of data copies.
-	DataOffset                         string     `json:"data_offset"`                 // Data offset on each image device.
-	NewDataOffset                      string     `json:"new_data_offset"`             // New data offset after any reshape on each image device.
-	ParityChunks                       IntString  `json:"parity_chunks"`               // Number of (rotating) parity chunks.
-	StripeSize                         string     `json:"stripe_size"`                 // For stripes, amount of data placed on one device before switching to the next.
-	RegionSize                         string     `json:"region_size"`                 // For mirrors/raids, the unit of data per leg when synchronizing devices.
-	ChunkSize                          string     `json:"chunk_size"`                  // For snapshots, the unit of data used when tracking changes.
-	ThinCount                          IntString  `json:"thin_count"`                  // For thin pools, the number of thin volumes in this pool.
-	Discards                           string     `json:"discards"`                    // For thin pools, how discards are handled.
-	CacheMetadataFormat                string     `json:"cache_metadata_format"`       // For cache, metadata format in use.
-	CacheMode                          string     `json:"cache_mode"`                  // For cache, how writes are cached.
-	Zero                               BoolString `json:"zero"`                        // For thin pools and volumes, if zeroing is enabled.
-	TransactionID                      string     `json:"transaction_id"`              // For thin pools, the transaction id and creation transaction id for thins.
-	ThinID                             string     `json:"thin_id"`                     // For thin volume, the thin device id.
-	SegmentStart                       string     `json:"seg_start"`                   // Offset within the LV to the start of the segment in current units.
-	SegmentStartExtents                string     `json:"seg_start_pe"`                // Offset within the LV to the start of the segment in physical extents.
-	SegmentSize                        string     `json:"seg_size"`                    // Size of segment in current units.
-	SegmentSizeExtents                 string     `json:"seg_size_pe"`                 // Size of segment in physical extents.
-	SegmentTags                        string     `json:"seg_tags"`                    // Tags, if any.
-	SegmentLogicalExtentRanges         string     `json:"seg_le_ranges"`               // Ranges of Logical Extents of underlying devices in command line format.
-	SegmentMetadataLogicalExtentRanges string     `json:"seg_metadata_le_ranges"`      // Ranges of Logical Extents of underlying metadata devices in command line format.
-	Devices                            string     `json:"devices"`                     // Underlying devices used with starting extent numbers.
-	MetadataDevices                    string     `json:"metadata_devices"`            // Underlying metadata devices used with starting extent numbers.
-	Monitor                            string     `json:"seg_monitor"`                 // Dmeventd monitoring status of the segment.
-	CachePolicy                        string     `json:"cache_policy"`                // The cache policy (cached segments only).
-	CacheSettings                      string     `json:"cache_settings"`              // Cache settings/parameters (cached segments only).
-	VDOCompression                     BoolString `json:"vdo_compression"`             // Set for compressed LV (vdopool).
-	VDODeduplication                   BoolString `json:"vdo_deduplication"`           // Set for deduplicated LV (vdopool).
+	UUID                               string          `json:"lv_uuid"`                     // Unique identifier.
+	Name                               string          `json:"lv_name"`                     // Name.  LVs created for internal use are enclosed in brackets.
+	FullName                           string          `json:"lv_full_name"`                // Full name of LV including its VG, namely VG/LV.
+	Path                               string          `json:"lv_path"`                     // Full pathname for LV. Blank for internal LVs.
+	DMPath                             string          `json:"lv_dm_path"`                  // Internal device//mapper pathname for LV (in /dev/mapper directory).
+	Parent                             string          `json:"lv_parent"`                   // For LVs that are components of another LV, the parent LV.
+	VGName                             string          `json:"vg_name"`                     // Name of the VG the LV belongs to.
+	Layout                             string          `json:"lv_layout"`                   // LV layout.
+	Role                               string          `json:"lv_role"`                     // LV role.
+	InitialImageSync                   BoolString      `json:"lv_initial_image_sync"`       // Set if mirror/RAID images underwent initial resynchronization.
+	ImageSynced                        BoolString      `json:"lv_image_synced"`             // Set if mirror/RAID image is synchronized.
+	Merging                            BoolString      `json:"lv_merging"`                  // Set if snapshot LV is being merged to origin.
+	Converting                         BoolString      `json:"lv_converting"`               // Set if LV is being converted.
+	AllocationPolicy                   string          `json:"lv_allocation_policy"`        // LV allocation policy.
+	AllocationLocked                   BoolString      `json:"lv_allocation_locked"`        // Set if LV is locked against allocation changes.
+	FixedMinor                         BoolString      `json:"lv_fixed_minor"`              // Set if LV has fixed minor number assigned.
+	SkipActivation                     BoolString      `json:"lv_skip_activation"`          // Set if LV is skipped on activation.
+	AutoActivation                     BoolString      `json:"lv_autoactivation"`           // Set if LV autoactivation is enabled.
+	WhenFull                           string          `json:"lv_when_full"`                // For thin pools, behavior when full.
+	Active                             string          `json:"lv_active"`                   // Active state of the LV.
+	ActiveLocally                      BoolString      `json:"lv_active_locally"`           // Set if the LV is active locally.
+	ActiveRemotely                     BoolString      `json:"lv_active_remotely"`          // Set if the LV is active remotely.
+	ActiveExclusively                  BoolString      `json:"lv_active_exclusively"`       // Set if the LV is active exclusively.
+	Major                              IntString       `json:"lv_major"`                    // Persistent major number or -1 if not persistent.
+	Minor                              IntString       `json:"lv_minor"`                    // Persistent minor number or -1 if not persistent.
+	ReadAhead                          string          `json:"lv_read_ahead"`               // Read ahead setting in current units.
+	Size                               string          `json:"lv_size"`                     // Size of LV in current units.
+	MetadataSize                       string          `json:"lv_metadata_size"`            // For thin and cache pools, the size of the LV that holds the metadata.
+	SegmentCount                       IntString       `json:"seg_count"`                   // Number of segments in LV.
+	Origin                             string          `json:"origin"`                      // For snapshots and thins, the origin device of this LV.
+	OriginUUID                         string          `json:"origin_uuid"`                 // For snapshots and thins, the UUID of origin device of this LV.
+	OriginSize                         string          `json:"origin_size"`                 // For snapshots, the size of the origin device of this LV.
+	Ancestors                          string          `json:"lv_ancestors"`                // LV ancestors ignoring any stored history of the ancestry chain.
+	FullAncestors                      string          `json:"lv_full_ancestors"`           // LV ancestors including stored history of the ancestry chain.
+	Descendants                        string          `json:"lv_descendants"`              // LV descendants ignoring any stored history of the ancestry chain.
+	FullDescendants                    string          `json:"lv_full_descendants"`         // LV descendants including stored history of the ancestry chain.
+	RAIDMismatchCount                  IntString       `json:"raid_mismatch_count"`         // For RAID, number of mismatches found or repaired.
+	RAIDSyncAction                     string          `json:"raid_sync_action"`            // For RAID, the current synchronization action being performed.
+	RAIDWriteBehind                    string          `json:"raid_write_behind"`           // For RAID1, the number of outstanding writes allowed to writemostly devices.
+	RAIDMinRecoveryRate                string          `json:"raid_min_recovery_rate"`      // For RAID1, the minimum recovery I/O load in kiB/sec/disk.
+	RAIDMaxRecoveryRate                string          `json:"raid_max_recovery_rate"`      // For RAID1, the maximum recovery I/O load in kiB/sec/disk.
+	RAIDIntegrityMode                  string          `json:"raidintegritymode"`           // The integrity mode
+	RAIDIntegrityBlockSize             string          `json:"raidintegrityblocksize"`      // The integrity block size
+	RAIDIntegrityMismatches            IntString       `json:"integritymismatches"`         // The number of integrity mismatches.
+	MovePV                             string          `json:"move_pv"`                     // For pvmove, Source PV of temporary LV created by pvmove.
+	MovePVUUID                         string          `json:"move_pv_uuid"`                // For pvmove, the UUID of Source PV of temporary LV created by pvmove.
+	ConvertLV                          string          `json:"convert_lv"`                  // For lvconvert, Name of temporary LV created by lvconvert.
+	ConvertLVUUID                      string          `json:"convert_lv_uuid"`             // For lvconvert, UUID of temporary LV created by lvconvert.
+	MirrorLog                          string          `json:"mirror_log"`                  // For mirrors, the LV holding the synchronisation log.
+	MirrorLogUUID                      string          `json:"mirror_log_uuid"`             // For mirrors, the UUID of the LV holding the synchronisation log.
+	DataLV                             string          `json:"data_lv"`                     // For cache/thin/vdo pools, the LV holding the associated data.
+	DataLVUUID                         string          `json:"data_lv_uuid"`                // For cache/thin/vdo pools, the UUID of the LV holding the associated data.
+	MetadataLV                         string          `json:"metadata_lv"`                 // For cache/thin pools, the LV holding the associated metadata.
+	MetadataLVUUID                     string          `json:"metadata_lv_uuid"`            // For cache/thin pools, the UUID of the LV holding the associated metadata.
+	PoolLV                             string          `json:"pool_lv"`                     // For cache/thin/vdo volumes, the cache/thin/vdo pool LV for this volume.
+	PoolLVUUID                         string          `json:"pool_lv_uuid"`                // For cache/thin/vdo volumes, the UUID of the cache/thin/vdo pool LV for this volume.
+	Tags                               string          `json:"lv_tags"`                     // Tags, if any.
+	Profile                            string          `json:"lv_profile"`                  // Configuration profile attached to this LV.
+	LockArgs                           string          `json:"lv_lockargs"`                 // Lock args of the LV used by lvmlockd.
+	CreationTime                       string          `json:"lv_time"`                     // Creation time of the LV, if known
+	RemovalTime                        string          `json:"lv_time_removed"`             // Removal time of the LV, if known
+	CreationHost                       string          `json:"lv_host"`                     // Creation host of the LV, if known.
+	RequiredModules                    string          `json:"lv_modules"`                  // Kernel device-mapper modules required for this LV.
+	Historical                         BoolString      `json:"lv_historical"`               // Set if the LV is historical.
+	WriteCacheBlockSize                string          `json:"writecache_block_size"`       // The writecache block size
+	KernelMajor                        string          `json:"lv_kernel_major"`             // Currently assigned major number or -1 if LV is not active.
+	KernelMinor                        string          `json:"lv_kernel_minor"`             // Currently assigned minor number or -1 if LV is not active.
+	KernelReadAhead                    string          `json:"lv_kernel_read_ahead"`        // Currently-in-use read ahead setting in current units.
+	Attributes                         string          `json:"lv_attr"`                     // LV attributes.
+	Permissions                        string          `json:"lv_permissions"`              // LV permissions, eg. `writeable` or `read-only`.
+	Suspended                          BoolString      `json:"lv_suspended"`                // Set if LV is suspended.
+	LiveTable                          BoolString      `json:"lv_live_table"`               // Set if LV has live table present.
+	InactiveTable                      BoolString      `json:"lv_inactive_table"`           // Set if LV has inactive table present.
+	DeviceOpen                         BoolString      `json:"lv_device_open"`              // Set if LV device is open.
+	DataPercent                        string          `json:"data_percent"`                // For snapshot, cache and thin pools and volumes, the percentage full if LV is active.
+	SnapshotPercent                    string          `json:"snap_percent"`                // For snapshots, the percentage full if LV is active.
+	MetadataPercent                    string          `json:"metadata_percent"`            // For cache and thin pools, the percentage of metadata full if LV is active.
+	CopyPercent                        NullFloatString `json:"copy_percent"`                // For Cache, RAID, mirrors and pvmove, current percentage in-sync.
+	SyncPercent                        NullFloatString `json:"sync_percent"`                // For Cache, RAID, mirrors and pvmove, current percentage in-sync.
+	CacheTotalBlocks                   IntString       `json:"cache_total_blocks"`          // Total cache blocks.
+	CacheUsedBlocks                    IntString       `json:"cache_used_blocks"`           // Used cache blocks.
+	CacheDirtyBlocks                   IntString       `json:"cache_dirty_blocks"`          // Dirty cache blocks.
+	CacheReadHits                      IntString       `json:"cache_read_hits"`             // Cache read hits.
+	CacheReadMisses                    IntString       `json:"cache_read_misses"`           // Cache read misses.
+	CacheWriteHits                     IntString       `json:"cache_write_hits"`            // Cache write hits.
+	CacheWriteMisses                   IntString       `json:"cache_write_misses"`          // Cache write misses.
+	KernelCacheSettings                string          `json:"kernel_cache_settings"`       // Cache settings/parameters as set in kernel, including default values (cached segments only).
+	KernelCachePolicy                  string          `json:"kernel_cache_policy"`         // Cache policy used in kernel.
+	KernelMetadataFormat               string          `json:"kernel_metadata_format"`      // Cache metadata format used in kernel.
+	HealthStatus                       LVHealth        `json:"lv_health_status"`            // LV health status.
+	KernelDiscards                     string          `json:"kernel_discards"`             // For thin pools, how discards are handled in kernel.
+	CheckNeeded                        string          `json:"lv_check_needed"`             // For thin pools and cache volumes, whether metadata check is needed.
+	MergeFailed                        BoolString      `json:"lv_merge_failed"`             // Set if snapshot merge failed.
+	SnapshotInvalid                    BoolString      `json:"lv_snapshot_invalid"`         // Set if snapshot LV is invalid.
+	VDOOperatingMode                   string          `json:"vdo_operating_mode"`          // For vdo pools, its current operating mode.
+	VDOCompressionState                string          `json:"vdo_compression_state"`       // For vdo pools, whether compression is running.
+	VDOIndexState                      string          `json:"vdo_index_state"`             // For vdo pools, state of index for deduplication.
+	VDOUsedSize                        string          `json:"vdo_used_size"`               // For vdo pools, currently used space.
+	VDOSavingPercent                   string          `json:"vdo_saving_percent"`          // For vdo pools, percentage of saved space.
+	WriteCacheTotalBlocks              IntString       `json:"writecache_total_blocks"`     // Total writecache blocks.
+	WriteCacheFreeBlocks               IntString       `json:"writecache_free_blocks"`      // Total writecache free blocks.
+	WriteCacheWritebackBlocks          IntString       `json:"writecache_writeback_blocks"` // Total writecache writeback blocks.
+	WriteCacheErrors                   IntString       `json:"writecache_error"`            // Total writecache errors.
+	Type                               LVType          `json:"segtype"`                     // Type of LV segment.
+	Stripes                            IntString       `json:"stripes"`                     // Number of stripes or mirror/raid1 legs.
+	DataStripes                        IntString       `json:"data_stripes"`                // Number of data stripes or mirror/raid1 legs.
+	ReshapeLength                      string          `json:"reshape_len"`                 // Size of out-of-place reshape space in current units.
+	ReshapeLengthExtents               string          `json:"reshape_len_le"`              // Size of out-of-place reshape space in logical extents.
+	DataCopies                         IntString       `json:"data_copies"`                 // Number of data copies.
+	DataOffset                         string          `json:"data_offset"`                 // Data offset on each image device.
+	NewDataOffset                      string          `json:"new_data_offset"`             // New data offset after any reshape on each image device.
+	ParityChunks                       IntString       `json:"parity_chunks"`               // Number of (rotating) parity chunks.
+	StripeSize                         string          `json:"stripe_size"`                 // For stripes, amount of data placed on one device before switching to the next.
+	RegionSize                         string          `json:"region_size"`                 // For mirrors/raids, the unit of data per leg when synchronizing devices.
+	ChunkSize                          string          `json:"chunk_size"`                  // For snapshots, the unit of data used when tracking changes.
+	ThinCount                          IntString       `json:"thin_count"`                  // For thin pools, the number of thin volumes in this pool.
+	Discards                           string          `json:"discards"`                    // For thin pools, how discards are handled.
+	CacheMetadataFormat                string          `json:"cache_metadata_format"`       // For cache, metadata format in use.
+	CacheMode                          string          `json:"cache_mode"`                  // For cache, how writes are cached.
+	Zero                               BoolString      `json:"zero"`                        // For thin pools and volumes, if zeroing is enabled.
+	TransactionID                      string          `json:"transaction_id"`              // For thin pools, the transaction id and creation transaction id for thins.
+	ThinID                             string          `json:"thin_id"`                     // For thin volume, the thin device id.
+	SegmentStart                       string          `json:"seg_start"`                   // Offset within the LV to the start of the segment in current units.
+	SegmentStartExtents                string          `json:"seg_start_pe"`                // Offset within the LV to the start of the segment in physical extents.
+	SegmentSize                        string          `json:"seg_size"`                    // Size of segment in current units.
+	SegmentSizeExtents                 string          `json:"seg_size_pe"`                 // Size of segment in physical extents.
+	SegmentTags                        string          `json:"seg_tags"`                    // Tags, if any.
+	SegmentLogicalExtentRanges         string          `json:"seg_le_ranges"`               // Ranges of Logical Extents of underlying devices in command line format.
+	SegmentMetadataLogicalExtentRanges string          `json:"seg_metadata_le_ranges"`      // Ranges of Logical Extents of underlying metadata devices in command line format.
+	Devices                            string          `json:"devices"`                     // Underlying devices used with starting extent numbers.
+	MetadataDevices                    string          `json:"metadata_devices"`            // Underlying metadata devices used with starting extent numbers.
+	Monitor                            string          `json:"seg_monitor"`                 // Dmeventd monitoring status of the segment.
+	CachePolicy                        string          `json:"cache_policy"`                // The cache policy (cached segments only).
+	CacheSettings                      string          `json:"cache_settings"`              // Cache settings/parameters (cached segments only).
+	VDOCompression                     BoolString      `json:"vdo_compression"`             // Set for compressed LV (vdopool).
+	VDODeduplication                   BoolString      `json:"vdo_deduplication"`           // Set for deduplicated LV (vdopool).
+	// The LV's segments, in order, as filled in by GetLogicalVolume. The report
+	// has a row for each segment, and the segment fields above are the first's.
+	Segments []LogicalVolume `json:"-"`
+}
+
+// Health returns the health of the LV, falling back to the health attribute
+// for versions of lvm that don't report the health status.
+func (lv LogicalVolume) Health() LVHealth {
+	if lv.HealthStatus != LVHealthOK {
+		return lv.HealthStatus
+	}
+
+	return lv.Attr().Health
+}
+
+// HasIntegrity returns true if the LV has dm-integrity checksums enabled.
+func (lv LogicalVolume) HasIntegrity() bool {
+	return lv.RAIDIntegrityMode != ""
+}
+
+// IsActive returns true if the LV is active, either locally or remotely.
+func (lv LogicalVolume) IsActive() bool {
+	if lv.Active != "" {
+		return lv.Active == "active"
+	}
+
+	return lv.Attr().State == LVStateActive
+}
+
+// IsOpen returns true if the LV device is open, eg. mounted or in use by a
+// process.
+func (lv LogicalVolume) IsOpen() bool {
+	return bool(lv.DeviceOpen) || lv.Attr().Open
+}
+
+// IsSnapshot returns true if the LV is a (thick) snapshot, including one that
+// is being merged into its origin.
+func (lv LogicalVolume) IsSnapshot() bool {
+	if lv.Type == LVTypeSnapshot {
+		return true
+	}
+
+	switch lv.Attr().VolumeType {
+	case LVVolumeTypeSnapshot, LVVolumeTypeMergingSnapshot:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsThinPool returns true if the LV is a thin pool.
+func (lv LogicalVolume) IsThinPool() bool {
+	return lv.Type == LVTypeThinPool || lv.Attr().VolumeType == LVVolumeTypeThinPool
+}
+
+// SnapPercent returns how full the copy-on-write space of a (thick) snapshot
+// is, as a percentage. It returns false if the LV isn't an active snapshot.
+func (lv LogicalVolume) SnapPercent() (float64, bool) {
+	if !lv.IsSnapshot() {
+		return 0, false
+	}
+
+	// Older versions of lvm only report the usage of snapshots in snap_percent.
+	for _, v := range []string{lv.DataPercent, lv.SnapshotPercent} {
+		if percent, err := strconv.ParseFloat(v, 64); err == nil {
+			return percent, true
+		}
+	}
+
+	return 0, false
+}
+
+// IsSnapshotFull returns true if a (thick) snapshot has run out of
+// copy-on-write space, which invalidates it.
+func (lv LogicalVolume) IsSnapshotFull() bool {
+	if !lv.IsSnapshot() {
+		return false
+	}
+
+	if lv.SnapshotInvalid {
+		return true
+	}
+
+	switch lv.Attr().State {
+	case LVStateInvalidSnapshot, LVStateSuspendedInvalidSnapshot:
+		return true
+	}
+
+	percent, ok := lv.SnapPercent()
+	return ok && percent >= 100
 }
 
 // ListLVOptions provides options for listing LVs (lvs).
 type ListLVOptions struct {
 	CommonOptions
-	Names                []string `arg:"0"`                    // Specific LVs to display.
-	History              bool     `arg:"history"`              // Include historical LVs if `record_lvs_history` is enabled.
-	All                  bool     `arg:"all"`                  // Display information about hidden internal LVs
-	Select               string   `arg:"select"`               // Filters objects based on criteria.
-	Foreign              bool     `arg:"foreign"`              // Lists foreign VGs.
-	IgnoreLockingFailure bool     `arg:"ignorelockingfailure"` // Whether to proceed in read-only mode after lock failures.
-	ReadOnly             bool     `arg:"readonly"`             // Read metadata without locks.
-	Shared               bool     `arg:"shared"`               // Displays shared VGs without active lvmlockd.
+	Names                []string `arg:"0" json:"names,omitempty"`                                   // Specific LVs to display.
+	History              bool     `arg:"history" json:"history,omitempty"`                           // Include historical LVs if `record_lvs_history` is enabled.
+	All                  bool     `arg:"all" json:"all,omitempty"`                                   // Display information about hidden internal LVs
+	Select               string   `arg:"select" json:"select,omitempty"`                             // Filters objects based on criteria, see Select.
+	Foreign              bool     `arg:"foreign" json:"foreign,omitempty"`                           // Lists foreign VGs.
+	IgnoreLockingFailure bool     `arg:"ignorelockingfailure" json:"ignoreLockingFailure,omitempty"` // Whether to proceed in read-only mode after lock failures.
+	ReadOnly             bool     `arg:"readonly" json:"readOnly,omitempty"`                         // Read metadata without locks.
+	Shared               bool     `arg:"shared" json:"shared,omitempty"`                             // Displays shared VGs without active lvmlockd.
+	Options              []string `arg:"options" json:"options,omitempty"`                           // Additional fields to report, alongside the default fields.
+	Active               *bool    `json:"active,omitempty"`                                          // Only list active LVs if true, or inactive LVs if false.
+}
+
+// withActive adds the activation state to the selection criteria.
+func (opts ListLVOptions) withActive() ListLVOptions {
+	if opts.Active == nil {
+		return opts
+	}
+
+	active := Select()
+	if *opts.Active {
+		active.Eq("lv_active", "active")
+	} else {
+		active.Ne("lv_active", "active")
+	}
+
+	if opts.Select != "" {
+		opts.Select = "(" + opts.Select + ") && " + active.String()
+	} else {
+		opts.Select = active.String()
+	}
+
+	return opts
 }
 
 // CreateLVOptions provides options for creating LVs (lvcreate).
 type CreateLVOptions struct {
 	CommonOptions
-	Name                   string   `arg:"name"`                   // Name of the LV to create.
-	VGName                 string   `arg:"0"`                      // Name of the VG to create the LV in.
-	Activate               *YesNo   `arg:"activate"`               // Activate the LV.
-	AutoBackup             *YesNo   `arg:"autobackup"`             // Auto backup metadata after changes.
-	Contiguous             *YesNo   `arg:"contiguous"`             // Allocate physical extents next to each other.
-	Persistent             *YesNo   `arg:"persistent"`             // Make the specified block device minor number persistent.
-	Major                  *int     `arg:"major"`                  // Major number of the LV block device.
-	Minor                  *int     `arg:"minor"`                  // Minor number of the LV block device.
-	SetActivationSkip      *YesNo   `arg:"setactivationskip"`      // Set the "activation skip" flag.
-	IgnoreActivationSkip   bool     `arg:"ignoreactivationskip"`   // Ignore the "activation skip" flag.
-	Permission             string   `arg:"permission"`             // Access permission, either read only `r` or read and write `rw`.
-	ReadAhead              string   `arg:"readahead"`              // Read-ahead sector count.
-	WipeSignatures         *YesNo   `arg:"wipesignatures"`         // Wipe existing filesystem signatures.
-	Zero                   *YesNo   `arg:"zero"`                   // Zero the first 4KiB of data in the new LV.
-	Tags                   []string `arg:"addtag"`                 // Tags to add to the LV.
-	Alloc                  string   `arg:"alloc"`                  // Allocation policy for Physical Extents.
-	SetAutoActivation      *YesNo   `arg:"setautoactivation"`      // Enable autoactivation for the LV.
-	IgnoreMonitoring       bool     `arg:"ignoremonitoring"`       // Ignore dmeventd monitoring.
-	NoUdevSync             bool     `arg:"noudevsync"`             // Ignore udev notifications.
-	Monitor                *YesNo   `arg:"monitor"`                // Toggle monitoring by dmeventd.
-	NoSync                 bool     `arg:"nosync"`                 // Skips initial sync for mirror, raid*; useful for empty volumes.
-	Type                   string   `arg:"type"`                   // Type of LV to create.
-	Size                   string   `arg:"size"`                   // Size of the LV.
-	Extents                string   `arg:"extents"`                // Size of the LV in logical extents.
-	Stripes                *int     `arg:"stripes"`                // Number of stripes in a striped LV.
-	StripeSize             string   `arg:"stripesize"`             // Amount of data that is written to one device before moving to the next.
-	MirrorLog              string   `arg:"mirrorlog"`              // The type of mirror log for mirrored LVs.
-	Mirrors                *int     `arg:"mirrors"`                // Number of mirror images in addition to the original LV image.
-	RegionSize             string   `arg:"regionsize"`             // Size of each raid or mirror synchronization region.
-	MinRecoveryRate        string   `arg:"minrecoveryrate"`        // Minimum recovery rate for a RAID LV.
-	MaxRecoveryRate        string   `arg:"maxrecoveryrate"`        // Maximum recovery rate for a RAID LV.
-	RAIDIntegrity          *YesNo   `arg:"raidintegrity"`          // Enable or disable data integrity checksums.
-	RAIDIntegrityMode      string   `arg:"raidintegritymode"`      // Chooses between using a journal (default) or bitmap for integrity checksums.
-	RAIDIntegrityBlockSize *int     `arg:"raidintegrityblocksize"` // Defines block size for dm-integrity on raid images.
-	Snapshot               bool     `arg:"snapshot"`               // Create a snapshot.
-	ChunkSize              string   `arg:"chunksize"`              // Size of chunks in a snapshot, cache pool or thin pool.
-	VirtualSize            string   `arg:"virtualsize"`            // Virtual size of a new thin LV.
-	Thin                   bool     `arg:"thin"`                   // Create a thin LV.
-	ThinPool               string   `arg:"thinpool"`               // Name of the thin pool LV.
-	Discards               string   `arg:"discards"`               // How the device-mapper thin pool layer in the kernel should handle discards.
-	ErrorWhenFull          *YesNo   `arg:"errorwhenfull"`          // Whether to fail when the thin pool is full.
-	PoolMetadataSize       string   `arg:"poolmetadatasize"`       // Specifies the size of the new pool metadata LV.
-	PoolMetadataSpare      *YesNo   `arg:"poolmetadataspare"`      // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
-	Cache                  bool     `arg:"cache"`                  // Specifies the command is handling a cache LV or cache pool.
-	CacheDevice            string   `arg:"cachedevice"`            // The PV to use for the cache.
-	CacheVol               string   `arg:"cachevol"`               // The name of the cache LV.
-	CacheMode              string   `arg:"cachemode"`              // When writes to a cache LV should be considered complete.
-	CachePolicy            string   `arg:"cachepolicy"`            // The cache policy to use.
-	CachePool              string   `arg:"cachepool"`              // The name of a cache pool.
-	CacheSettings          []string `arg:"cachesettings"`          // Cache settings in `key=value` format.
-	CacheSize              string   `arg:"cachesize"`              // Size of the cache LV.
-	VDO                    bool     `arg:"vdo"`                    // Specifies the command is handling a VDO LV.
-	VDOPool                string   `arg:"vdopool"`                // The name of the VDO pool LV.
-	VDOSettings            []string `arg:"vdosettings"`            // VDO settings in `key=value` format.
-	Compression            *YesNo   `arg:"compression"`            // Whether to enable compression.
-	Deduplication          *YesNo   `arg:"deduplication"`          // Whether to enable deduplication.
+	Name                   string        `arg:"name" json:"name,omitempty"`                                     // Name of the LV to create.
+	VGName                 string        `arg:"0" json:"vgName,omitempty"`                                      // Name of the VG to create the LV in.
+	PVNames                []string      `arg:"1" json:"pvNames,omitempty"`                                     // Specific PVs (optionally with extent ranges, eg. /dev/sdb:0-99) to allocate from.
+	Activate               Activation    `arg:"activate" json:"activate,omitempty"`                             // Activate the LV. With No, it has no device node (and isn't zeroed) until activated with UpdateLogicalVolume.
+	AutoBackup             *YesNo        `arg:"autobackup" json:"autoBackup,omitempty"`                         // Auto backup metadata after changes.
+	Contiguous             *YesNo        `arg:"contiguous" json:"contiguous,omitempty"`                         // Allocate physical extents next to each other.
+	Persistent             *YesNo        `arg:"persistent" json:"persistent,omitempty"`                         // Make the specified block device minor number persistent.
+	Major                  *int          `arg:"major" json:"major,omitempty"`                                   // Major number of the LV block device.
+	Minor                  *int          `arg:"minor" json:"minor,omitempty"`                                   // Minor number of the LV block device.
+	SetActivationSkip      *YesNo        `arg:"setactivationskip" json:"setActivationSkip,omitempty"`           // Set the "activation skip" flag.
+	IgnoreActivationSkip   bool          `arg:"ignoreactivationskip" json:"ignoreActivationSkip,omitempty"`     // Ignore the "activation skip" flag.
+	Permission             string        `arg:"permission" json:"permission,omitempty"`                         // Access permission, either read only `r` or read and write `rw`.
+	ReadAhead              string        `arg:"readahead" json:"readAhead,omitempty"`                           // Read-ahead sector count.
+	WipeSignatures         *YesNo        `arg:"wipesignatures" json:"wipeSignatures,omitempty"`                 // Wipe existing filesystem signatures.
+	Zero                   *YesNo        `arg:"zero" json:"zero,omitempty"`                                     // Zero the first 4KiB of data in the new LV.
+	Tags                   []string      `arg:"addtag" json:"tags,omitempty"`                                   // Tags to add to the LV.
+	Alloc                  string        `arg:"alloc" json:"alloc,omitempty"`                                   // Allocation policy for Physical Extents.
+	SetAutoActivation      *YesNo        `arg:"setautoactivation" json:"setAutoActivation,omitempty"`           // Enable autoactivation for the LV.
+	IgnoreMonitoring       bool          `arg:"ignoremonitoring" json:"ignoreMonitoring,omitempty"`             // Ignore dmeventd monitoring.
+	NoUdevSync             bool          `arg:"noudevsync" json:"noUdevSync,omitempty"`                         // Ignore udev notifications.
+	Monitor                *YesNo        `arg:"monitor" json:"monitor,omitempty"`                               // Toggle monitoring by dmeventd.
+	NoSync                 bool          `arg:"nosync" json:"noSync,omitempty"`                                 // Skips initial sync for mirror, raid*; useful for empty volumes.
+	Type                   LVType        `arg:"type" json:"type,omitempty"`                                     // Type of LV to create.
+	Size                   string        `arg:"size" json:"size,omitempty"`                                     // Size of the LV.
+	Extents                string        `arg:"extents" json:"extents,omitempty"`                               // Size of the LV in logical extents.
+	Stripes                *int          `arg:"stripes" json:"stripes,omitempty"`                               // Number of stripes in a striped LV.
+	StripeSize             string        `arg:"stripesize" json:"stripeSize,omitempty"`                         // Amount of data that is written to one device before moving to the next.
+	MirrorLog              string        `arg:"mirrorlog" json:"mirrorLog,omitempty"`                           // The type of mirror log for mirrored LVs.
+	Mirrors                *int          `arg:"mirrors" json:"mirrors,omitempty"`                               // Number of mirror images in addition to the original LV image.
+	RegionSize             string        `arg:"regionsize" json:"regionSize,omitempty"`                         // Size of each raid or mirror synchronization region.
+	MinRecoveryRate        string        `arg:"minrecoveryrate" json:"minRecoveryRate,omitempty"`               // Minimum recovery rate for a RAID LV.
+	MaxRecoveryRate        string        `arg:"maxrecoveryrate" json:"maxRecoveryRate,omitempty"`               // Maximum recovery rate for a RAID LV.
+	RAIDIntegrity          *YesNo        `arg:"raidintegrity" json:"raidIntegrity,omitempty"`                   // Enable or disable data integrity checksums.
+	RAIDIntegrityMode      string        `arg:"raidintegritymode" json:"raidIntegrityMode,omitempty"`           // Chooses between using a journal (default) or bitmap for integrity checksums.
+	RAIDIntegrityBlockSize *int          `arg:"raidintegrityblocksize" json:"raidIntegrityBlockSize,omitempty"` // Defines block size for dm-integrity on raid images.
+	Snapshot               bool          `arg:"snapshot" json:"snapshot,omitempty"`                             // Create a snapshot.
+	ChunkSize              string        `arg:"chunksize" json:"chunkSize,omitempty"`                           // Size of chunks in a snapshot, cache pool or thin pool.
+	VirtualSize            string        `arg:"virtualsize" json:"virtualSize,omitempty"`                       // Virtual size of a new thin LV.
+	Thin                   bool          `arg:"thin" json:"thin,omitempty"`                                     // Create a thin LV.
+	ThinPool               string        `arg:"thinpool" json:"thinPool,omitempty"`                             // Name of the thin pool LV.
+	Discards               string        `arg:"discards" json:"discards,omitempty"`                             // How the device-mapper thin pool layer in the kernel should handle discards.
+	ErrorWhenFull          *YesNo        `arg:"errorwhenfull" json:"errorWhenFull,omitempty"`                   // Whether to fail when the thin pool is full.
+	PoolMetadataSize       string        `arg:"poolmetadatasize" json:"poolMetadataSize,omitempty"`             // Specifies the size of the new pool metadata LV.
+	PoolMetadataSpare      *YesNo        `arg:"poolmetadataspare" json:"poolMetadataSpare,omitempty"`           // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
+	Cache                  bool          `arg:"cache" json:"cache,omitempty"`                                   // Specifies the command is handling a cache LV or cache pool.
+	CacheDevice            string        `arg:"cachedevice" json:"cacheDevice,omitempty"`                       // The PV to use for the cache.
+	CacheVol               string        `arg:"cachevol" json:"cacheVol,omitempty"`                             // The name of the cache LV.
+	CacheMode              string        `arg:"cachemode" json:"cacheMode,omitempty"`                           // When writes to a cache LV should be considered complete.
+	CachePolicy            string        `arg:"cachepolicy" json:"cachePolicy,omitempty"`                       // The cache policy to use.
+	CachePool              string        `arg:"cachepool" json:"cachePool,omitempty"`                           // The name of a cache pool.
+	CacheSettings          CacheSettings `arg:"cachesettings" json:"cacheSettings,omitempty"`                   // Tunables for the cache policy.
+	CacheSize              string        `arg:"cachesize" json:"cacheSize,omitempty"`                           // Size of the cache LV.
+	VDO                    bool          `arg:"vdo" json:"vdo,omitempty"`                                       // Specifies the command is handling a VDO LV.
+	VDOPool                string        `arg:"vdopool" json:"vdoPool,omitempty"`                               // The name of the VDO pool LV.
+	VDOSettings            []string      `arg:"vdosettings" json:"vdoSettings,omitempty"`                       // VDO settings in `key=value` format.
+	Compression            *YesNo        `arg:"compression" json:"compression,omitempty"`                       // Whether to enable compression.
+	Deduplication          *YesNo        `arg:"deduplication" json:"deduplication,omitempty"`                   // Whether to enable deduplication.
+	AllocationTags         []string      `json:"allocationTags,omitempty"`                                      // Only allocate from PVs with any of these tags, in addition to PVNames.
+}
+
+// withAllocationTags appends the allocation tags to the PVs, as lvcreate
+// expects them in the form @tag.
+func (opts CreateLVOptions) withAllocationTags() CreateLVOptions {
+	if len(opts.AllocationTags) > 0 {
+		pvNames := append([]string{}, opts.PVNames...)
+		for _, tag := range opts.AllocationTags {
+			pvNames = append(pvNames, "@"+tag)
+		}
+		opts.PVNames = pvNames
+	}
+
+	return opts
 }
 
 // UpdateLVOptions provides options for modifying LVs (lvchange).
 type UpdateLVOptions struct {
 	CommonOptions
-	Name                 string   `arg:"0"`                    // Name of the LV to modify.
-	Force                bool     `arg:"force"`                // Override checks and protections.
-	Select               string   `arg:"select"`               // Filters objects based on criteria.
-	Refresh              bool     `arg:"refresh"`              // Refreshes the LV metadata.
-	Activate             *YesNo   `arg:"activate"`             // Activate the LV.
-	AutoBackup           *YesNo   `arg:"autobackup"`           // Auto backup metadata after changes.
-	Contiguous           *YesNo   `arg:"contiguous"`           // Allocate physical extents next to each other.
-	Persistent           *YesNo   `arg:"persistent"`           // Make the specified block device minor number persistent.
-	Major                *int     `arg:"major"`                // Major number of the LV block device.
-	Minor                *int     `arg:"minor"`                // Minor number of the LV block device.
-	SetActivationSkip    *YesNo   `arg:"setactivationskip"`    // Set the "activation skip" flag.
-	IgnoreActivationSkip bool     `arg:"ignoreactivationskip"` // Ignore the "activation skip" flag.
-	Permission           string   `arg:"permission"`           // Access permission, either read only `r` or read and write `rw`.
-	ReadAhead            string   `arg:"readahead"`            // Read-ahead sector count.
-	Zero                 *YesNo   `arg:"zero"`                 // Set zeroing mode for thin pool.
-	AddTags              []string `arg:"addtag"`               // Add tag/s to the LV.
-	DelTags              []string `arg:"deltag"`               // Remove tag/s from the LV.
-	Alloc                string   `arg:"alloc"`                // Allocation policy for Physical Extents.
-	DetachProfile        bool     `arg:"detachprofile"`        // Detach a metadata profile.
-	Partial              bool     `arg:"partial"`              // Attempt activation with missing Physical Extents.
-	ActivationMode       string   `arg:"activationmode"`       // Conditions under which a LV can be activated with missing PVs.
-	SetAutoActivation    *YesNo   `arg:"setautoactivation"`    // Enable autoactivation for the LV.
-	Poll                 *YesNo   `arg:"poll"`                 // Resume background operations that were halted due to disruptions.
-	IgnoreMonitoring     bool     `arg:"ignoremonitoring"`     // Ignore dmeventd monitoring.
-	NoUdevSync           bool     `arg:"noudevsync"`           // Ignore udev notifications.
-	Monitor              *YesNo   `arg:"monitor"`              // Toggle monitoring by dmeventd.
-	Resync               bool     `arg:"resync"`               // Initiate mirror synchronization.
-	MinRecoveryRate      string   `arg:"minrecoveryrate"`      // Minimum recovery rate for a RAID LV.
-	MaxRecoveryRate      string   `arg:"maxrecoveryrate"`      // Maximum recovery rate for a RAID LV.
-	WriteBehind          *int     `arg:"writebehind"`          // Maximum number of outstanding writes that are allowed to devices in a RAID1 LV that is marked write-mostly.
-	WriteMostly          []string `arg:"writemostly"`          // Mark a device in a RAID1 LV as write-mostly.
-	Rebuild              string   `arg:"rebuild"`              // Selects a PV to rebuild in a raid LV.
-	SyncAction           string   `arg:"syncaction"`           // Initiate different types of RAID synchronization.
-	Discards             string   `arg:"discards"`             // How the device-mapper thin pool layer in the kernel should handle discards.
-	ErrorWhenFull        *YesNo   `arg:"errorwhenfull"`        // Whether to fail when the thin pool is full.
-	CacheMode            string   `arg:"cachemode"`            // When writes to a cache LV should be considered complete.
-	CachePolicy          string   `arg:"cachepolicy"`          // The cache policy to use.
-	CacheSettings        string   `arg:"cachesettings"`        // Cache settings in `key=value` format.
-	Compression          *YesNo   `arg:"compression"`          // Whether to enable compression.
-	Deduplication        *YesNo   `arg:"deduplication"`        // Whether to enable deduplication.
-	VDOSettings          string   `arg:"vdosettings"`          // VDO settings in `key=value` format.
-	SysInit              bool     `arg:"sysinit"`              // Indicates that the command is being invoked from early system init scripts.
-	IgnoreLockingFailure bool     `arg:"ignorelockingfailure"` // Whether to proceed in read-only mode after lock failures.
-	ReadOnly             bool     `arg:"readonly"`             // Read metadata without locks.
+	Name                 string        `arg:"0" json:"name,omitempty"`                                    // Name of the LV to modify.
+	Force                bool          `arg:"force" json:"force,omitempty"`                               // Override checks and protections.
+	Select               string        `arg:"select" json:"select,omitempty"`                             // Filters objects based on criteria, see Select.
+	Refresh              bool          `arg:"refresh" json:"refresh,omitempty"`                           // Refreshes the LV metadata.
+	Activate             Activation    `arg:"activate" json:"activate,omitempty"`                         // Activate the LV.
+	AutoBackup           *YesNo        `arg:"autobackup" json:"autoBackup,omitempty"`                     // Auto backup metadata after changes.
+	Contiguous           *YesNo        `arg:"contiguous" json:"contiguous,omitempty"`                     // Allocate physical extents next to each other.
+	Persistent           *YesNo        `arg:"persistent" json:"persistent,omitempty"`                     // Make the specified block device minor number persistent.
+	Major                *int          `arg:"major" json:"major,omitempty"`                               // Major number of the LV block device.
+	Minor                *int          `arg:"minor" json:"minor,omitempty"`                               // Minor number of the LV block device.
+	SetActivationSkip    *YesNo        `arg:"setactivationskip" json:"setActivationSkip,omitempty"`       // Set the "activation skip" flag.
+	IgnoreActivationSkip bool          `arg:"ignoreactivationskip" json:"ignoreActivationSkip,omitempty"` // Ignore the "activation skip" flag.
+	Permission           string        `arg:"permission" json:"permission,omitempty"`                     // Access permission, either read only `r` or read and write `rw`.
+	ReadAhead            string        `arg:"readahead" json:"readAhead,omitempty"`                       // Read-ahead sector count.
+	Zero                 *YesNo        `arg:"zero" json:"zero,omitempty"`                                 // Set zeroing mode for thin pool.
+	AddTags              []string      `arg:"addtag" json:"addTags,omitempty"`                            // Add tag/s to the LV.
+	DelTags              []string      `arg:"deltag" json:"delTags,omitempty"`                            // Remove tag/s from the LV.
+	Alloc                string        `arg:"alloc" json:"alloc,omitempty"`                               // Allocation policy for Physical Extents.
+	MetadataProfile      string        `arg:"metadataprofile" json:"metadataProfile,omitempty"`           // Attach a metadata profile.
+	DetachProfile        bool          `arg:"detachprofile" json:"detachProfile,omitempty"`               // Detach a metadata profile.
+	Partial              bool          `arg:"partial" json:"partial,omitempty"`                           // Attempt activation with missing Physical Extents.
+	IgnoreSkippedCluster bool          `arg:"ignoreskippedcluster" json:"ignoreSkippedCluster,omitempty"` // Don't fail if clustered VGs are skipped.
+	ActivationMode       string        `arg:"activationmode" json:"activationMode,omitempty"`             // Conditions under which a LV can be activated with missing PVs.
+	SetAutoActivation    *YesNo        `arg:"setautoactivation" json:"setAutoActivation,omitempty"`       // Enable autoactivation for the LV.
+	Poll                 *YesNo        `arg:"poll" json:"poll,omitempty"`                                 // Resume background operations that were halted due to disruptions.
+	IgnoreMonitoring     bool          `arg:"ignoremonitoring" json:"ignoreMonitoring,omitempty"`         // Ignore dmeventd monitoring.
+	NoUdevSync           bool          `arg:"noudevsync" json:"noUdevSync,omitempty"`                     // Ignore udev notifications.
+	Monitor              *YesNo        `arg:"monitor" json:"monitor,omitempty"`                           // Toggle monitoring by dmeventd.
+	Resync               bool          `arg:"resync" json:"resync,omitempty"`                             // Initiate mirror synchronization.
+	MinRecoveryRate      string        `arg:"minrecoveryrate" json:"minRecoveryRate,omitempty"`           // Minimum recovery rate for a RAID LV.
+	MaxRecoveryRate      string        `arg:"maxrecoveryrate" json:"maxRecoveryRate,omitempty"`           // Maximum recovery rate for a RAID LV.
+	WriteBehind          *int          `arg:"writebehind" json:"writeBehind,omitempty"`                   // Maximum number of outstanding writes that are allowed to devices in a RAID1 LV that is marked write-mostly.
+	WriteMostly          []string      `arg:"writemostly" json:"writeMostly,omitempty"`                   // Mark a device in a RAID1 LV as write-mostly.
+	Rebuild              string        `arg:"rebuild" json:"rebuild,omitempty"`                           // Selects a PV to rebuild in a raid LV.
+	SyncAction           string        `arg:"syncaction" json:"syncAction,omitempty"`                     // Initiate different types of RAID synchronization.
+	Discards             string        `arg:"discards" json:"discards,omitempty"`                         // How the device-mapper thin pool layer in the kernel should handle discards.
+	ErrorWhenFull        *YesNo        `arg:"errorwhenfull" json:"errorWhenFull,omitempty"`               // Whether to fail when the thin pool is full.
+	CacheMode            string        `arg:"cachemode" json:"cacheMode,omitempty"`                       // When writes to a cache LV should be considered complete.
+	CachePolicy          string        `arg:"cachepolicy" json:"cachePolicy,omitempty"`                   // The cache policy to use.
+	CacheSettings        CacheSettings `arg:"cachesettings" json:"cacheSettings,omitempty"`               // Tunables for the cache policy.
+	Compression          *YesNo        `arg:"compression" json:"compression,omitempty"`                   // Whether to enable compression.
+	Deduplication        *YesNo        `arg:"deduplication" json:"deduplication,omitempty"`               // Whether to enable deduplication.
+	VDOSettings          string        `arg:"vdosettings" json:"vdoSettings,omitempty"`                   // VDO settings in `key=value` format.
+	SysInit              bool          `arg:"sysinit" json:"sysInit,omitempty"`                           // Indicates that the command is being invoked from early system init scripts.
+	IgnoreLockingFailure bool          `arg:"ignorelockingfailure" json:"ignoreLockingFailure,omitempty"` // Whether to proceed in read-only mode after lock failures.
+	ReadOnly             bool          `arg:"readonly" json:"readOnly,omitempty"`                         // Read metadata without locks.
+}
+
+// ScrubAction is a RAID synchronization action used when scrubbing an LV.
+type ScrubAction string
+
+const (
+	// ScrubCheck reads the LV and counts any inconsistencies without correcting them.
+	ScrubCheck ScrubAction = "check"
+	// ScrubRepair reads the LV and corrects any inconsistencies.
+	ScrubRepair ScrubAction = "repair"
+)
+
+func (a ScrubAction) MarshalArg() string {
+	return string(a)
+}
+
+// ScrubLVOptions provides options for scrubbing RAID LVs (lvchange --syncaction).
+type ScrubLVOptions struct {
+	CommonOptions
+	Name   string      `arg:"0" json:"name,omitempty"`            // Name of the LV to scrub.
+	Action ScrubAction `arg:"syncaction" json:"action,omitempty"` // Whether to check or repair the LV.
 }
 
 // RemoveLVOptions provides options for removing LVs (lvremove).
 type RemoveLVOptions struct {
 	CommonOptions
-	Name       string `arg:"0"`          // Name of the LV to remove.
-	AutoBackup *YesNo `arg:"autobackup"` // Auto backup metadata after changes.
-	Force      bool   `arg:"force"`      // Override checks and protections.
-	Select     string `arg:"select"`     // Filters objects based on criteria.
-	NoHistory  bool   `arg:"nohistory"`  // Do not record history of LV being removed.
-	NoUdevSync bool   `arg:"noudevsync"` // Ignore udev notifications.
+	Name       string `arg:"0" json:"name,omitempty"`                // Name of the LV to remove.
+	AutoBackup *YesNo `arg:"autobackup" json:"autoBackup,omitempty"` // Auto backup metadata after changes.
+	Force      bool   `arg:"force" json:"force,omitempty"`           // Override checks and protections.
+	Select     string `arg:"select" json:"select,omitempty"`         // Filters objects based on criteria, see Select.
+	NoHistory  bool   `arg:"nohistory" json:"noHistory,omitempty"`   // Do not record history of LV being removed.
+	NoUdevSync bool   `arg:"noudevsync" json:"noUdevSync,omitempty"` // Ignore udev notifications.
 }
 
 // ConvertLVLayoutOptions provides options for changing LV layouts (lvconvert).
 type ConvertLVLayoutOptions struct {
 	CommonOptions
-	Name                   string   `arg:"0"`                      // Name of the LV to convert.
-	NewName                string   `arg:"name"`                   // The name of the new LV. When unspecified one is generated.
-	PVNames                []string `arg:"1"`                      // Specific PVs to convert.
-	Background             bool     `arg:"background"`             // Run conversion in background.
-	Interval               *int     `arg:"interval"`               // Report progress at regular intervals.
-	StartPoll              bool     `arg:"startpoll"`              // Start polling an LV to continue processing a conversion.
-	Force                  bool     `arg:"force"`                  // Override checks and protections.
-	UsePolicies            bool     `arg:"usepolicies"`            // Use the policy configured in lvm.conf or a profile.
-	Stripes                *int     `arg:"stripes"`                // Number of stripes in a striped LV.
-	StripeSize             string   `arg:"stripesize"`             // Amount of data that is written to one device before moving to the next.
-	MirrorLog              string   `arg:"mirrorlog"`              // The type of mirror log for mirrored LVs.
-	Mirrors                *int     `arg:"mirrors"`                // Number of mirror images in addition to the original LV image.
-	RegionSize             string   `arg:"regionsize"`             // Size of each raid or mirror synchronization region.
-	Alloc                  string   `arg:"alloc"`                  // Allocation policy for Physical Extents.
-	NoUdevSync             bool     `arg:"noudevsync"`             // Ignore udev notifications.
-	Type                   string   `arg:"type"`                   // Type of LV to convert to.
-	ReadAhead              string   `arg:"readahead"`              // Read-ahead sector count.
-	Zero                   *YesNo   `arg:"zero"`                   // For snapshots, zero the first 4KiB (unless read-only); for thin pools, zero newly provisioned blocks.
-	RAIDIntegrity          *YesNo   `arg:"raidintegrity"`          // Enable or disable data integrity checksums.
-	RAIDIntegrityMode      string   `arg:"raidintegritymode"`      // Chooses between using a journal (default) or bitmap for integrity checksums.
-	RAIDIntegrityBlockSize *int     `arg:"raidintegrityblocksize"` // Defines block size for dm-integrity on raid images.
-	Snapshot               bool     `arg:"snapshot"`               // Combine a former COW snapshot LV with a former origin LV.
-	ChunkSize              string   `arg:"chunksize"`              // Size of chunks in a snapshot, cache pool or thin pool.
-	VirtualSize            string   `arg:"virtualsize"`            // Virtual size of a new thin LV.
-	Thin                   bool     `arg:"thin"`                   // Create a thin LV.
-	ThinPool               string   `arg:"thinpool"`               // Name of the thin pool LV.
-	Discards               string   `arg:"discards"`               // How the device-mapper thin pool layer in the kernel should handle discards.
-	ErrorWhenFull          *YesNo   `arg:"errorwhenfull"`          // Whether to fail when the thin pool is full.
-	OriginName             string   `arg:"originname"`             // Specifies the name to use for the external origin LV when converting an LV to a thin LV.
-	PoolMetadata           string   `arg:"poolmetadata"`           // The name of a an LV to use for storing pool metadata.
-	PoolMetadataSize       string   `arg:"poolmetadatasize"`       // Specifies the size of the new pool metadata LV.
-	PoolMetadataSpare      *YesNo   `arg:"poolmetadataspare"`      // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
-	SwapMetadata           bool     `arg:"swapmetadata"`           // Extracts the metadata LV from a pool and replaces it with another specified LV.
-	Cache                  bool     `arg:"cache"`                  // Specifies the command is handling a cache LV or cache pool.
-	CacheDevice            string   `arg:"cachedevice"`            // The PV to use for the cache.
-	CacheVol               string   `arg:"cachevol"`               // The name of the cache LV.
-	CacheMode              string   `arg:"cachemode"`              // When writes to a cache LV should be considered complete.
-	CachePolicy            string   `arg:"cachepolicy"`            // The cache policy to use.
-	CachePool              string   `arg:"cachepool"`              // The name of a cache pool.
-	CacheSettings          []string `arg:"cachesettings"`          // Cache settings in `key=value` format.
-	CacheSize              string   `arg:"cachesize"`              // Size of the cache LV.
-	VDOPool                string   `arg:"vdopool"`                // The name of the VDO pool LV.
-	VDOSettings            []string `arg:"vdosettings"`            // VDO settings in `key=value` format.
-	Compression            *YesNo   `arg:"compression"`            // Whether to enable compression.
-	Deduplication          *YesNo   `arg:"deduplication"`          // Whether to enable deduplication.
-	Merge                  bool     `arg:"merge"`                  // An alias for MergeMirrors, MergeSnapshot, or MergeThin depending on LV type.
-	MergeMirrors           bool     `arg:"mergemirrors"`           // Merge LV images that were split from a raid1 LV.
-	MergeSnapshot          bool     `arg:"-mergesnapshot"`         // Merge COW snapshot LV into its origin.
-	MergeThin              bool     `arg:"mergethin"`              // Merge thin LV into its origin LV.
-	SplitCache             bool     `arg:"splitcache"`             // Separates a cache pool from a cache LV, and keeps the unused cache pool LV.
-	SplitMirrors           *int     `arg:"splitmirrors"`           // Splits the specified number of images from a raid1 or mirror LV and uses them to create a new LV.
-	SplitSnapshot          bool     `arg:"splitsnapshot"`          // Separates a COW snapshot from its origin LV.
-	Uncache                bool     `arg:"uncache"`                // Separates a cache pool from a cache LV, and deletes the unused cache pool LV.
-	TrackChanges           bool     `arg:"trackchanges"`           // Tracks changes to a raid1 LV while the split images remain detached.
-	Repair                 bool     `arg:"repair"`                 // Replace failed PVs in a raid or mirror LV, or run a repair utility on a thin pool.
-	Replace                string   `arg:"replace"`                // Replace a specific PV in a raid LV with another PV.
+	Name                   string        `arg:"0" json:"name,omitempty"`                                        // Name of the LV to convert.
+	NewName                string        `arg:"name" json:"newName,omitempty"`                                  // The name of the new LV. When unspecified one is generated.
+	PVNames                []string      `arg:"1" json:"pvNames,omitempty"`                                     // Specific PVs to convert.
+	Background             bool          `arg:"background" json:"background,omitempty"`                         // Run conversion in background.
+	Interval               *int          `arg:"interval" json:"interval,omitempty"`                             // Report progress at regular intervals.
+	StartPoll              bool          `arg:"startpoll" json:"startPoll,omitempty"`                           // Start polling an LV to continue processing a conversion.
+	Force                  bool          `arg:"force" json:"force,omitempty"`                                   // Override checks and protections.
+	UsePolicies            bool          `arg:"usepolicies" json:"usePolicies,omitempty"`                       // Use the policy configured in lvm.conf or a profile.
+	Stripes                *int          `arg:"stripes" json:"stripes,omitempty"`                               // Number of stripes in a striped LV.
+	StripeSize             string        `arg:"stripesize" json:"stripeSize,omitempty"`                         // Amount of data that is written to one device before moving to the next.
+	MirrorLog              string        `arg:"mirrorlog" json:"mirrorLog,omitempty"`                           // The type of mirror log for mirrored LVs.
+	Mirrors                *int          `arg:"mirrors" json:"mirrors,omitempty"`                               // Number of mirror images in addition to the original LV image.
+	RegionSize             string        `arg:"regionsize" json:"regionSize,omitempty"`                         // Size of each raid or mirror synchronization region.
+	Alloc                  string        `arg:"alloc" json:"alloc,omitempty"`                                   // Allocation policy for Physical Extents.
+	NoUdevSync             bool          `arg:"noudevsync" json:"noUdevSync,omitempty"`                         // Ignore udev notifications.
+	Type                   LVType        `arg:"type" json:"type,omitempty"`                                     // Type of LV to convert to.
+	ReadAhead              string        `arg:"readahead" json:"readAhead,omitempty"`                           // Read-ahead sector count.
+	Zero                   *YesNo        `arg:"zero" json:"zero,omitempty"`                                     // For snapshots, zero the first 4KiB (unless read-only); for thin pools, zero newly provisioned blocks.
+	RAIDIntegrity          *YesNo        `arg:"raidintegrity" json:"raidIntegrity,omitempty"`                   // Enable or disable data integrity checksums.
+	RAIDIntegrityMode      string        `arg:"raidintegritymode" json:"raidIntegrityMode,omitempty"`           // Chooses between using a journal (default) or bitmap for integrity checksums.
+	RAIDIntegrityBlockSize *int          `arg:"raidintegrityblocksize" json:"raidIntegrityBlockSize,omitempty"` // Defines block size for dm-integrity on raid images.
+	Snapshot               bool          `arg:"snapshot" json:"snapshot,omitempty"`                             // Combine a former COW snapshot LV with a former origin LV.
+	ChunkSize              string        `arg:"chunksize" json:"chunkSize,omitempty"`                           // Size of chunks in a snapshot, cache pool or thin pool.
+	VirtualSize            string        `arg:"virtualsize" json:"virtualSize,omitempty"`                       // Virtual size of a new thin LV.
+	Thin                   bool          `arg:"thin" json:"thin,omitempty"`                                     // Create a thin LV.
+	ThinPool               string        `arg:"thinpool" json:"thinPool,omitempty"`                             // Name of the thin pool LV.
+	Discards               string        `arg:"discards" json:"discards,omitempty"`                             // How the device-mapper thin pool layer in the kernel should handle discards.
+	ErrorWhenFull          *YesNo        `arg:"errorwhenfull" json:"errorWhenFull,omitempty"`                   // Whether to fail when the thin pool is full.
+	OriginName             string        `arg:"originname" json:"originName,omitempty"`                         // Specifies the name to use for the external origin LV when converting an LV to a thin LV.
+	PoolMetadata           string        `arg:"poolmetadata" json:"poolMetadata,omitempty"`                     // The name of a an LV to use for storing pool metadata.
+	PoolMetadataSize       string        `arg:"poolmetadatasize" json:"poolMetadataSize,omitempty"`             // Specifies the size of the new pool metadata LV.
+	PoolMetadataSpare      *YesNo        `arg:"poolmetadataspare" json:"poolMetadataSpare,omitempty"`           // Toggles the automtic creation and management of a spare pool metadata LV in the VG.
+	SwapMetadata           bool          `arg:"swapmetadata" json:"swapMetadata,omitempty"`                     // Extracts the metadata LV from a pool and replaces it with another specified LV.
+	Cache                  bool          `arg:"cache" json:"cache,omitempty"`                                   // Specifies the command is handling a cache LV or cache pool.
+	CacheDevice            string        `arg:"cachedevice" json:"cacheDevice,omitempty"`                       // The PV to use for the cache.
+	CacheVol               string        `arg:"cachevol" json:"cacheVol,omitempty"`                             // The name of the cache LV.
+	CacheMode              string        `arg:"cachemode" json:"cacheMode,omitempty"`                           // When writes to a cache LV should be considered complete.
+	CachePolicy            string        `arg:"cachepolicy" json:"cachePolicy,omitempty"`                       // The cache policy to use.
+	CachePool              string        `arg:"cachepool" json:"cachePool,omitempty"`                           // The name of a cache pool.
+	CacheSettings          CacheSettings `arg:"cachesettings" json:"cacheSettings,omitempty"`                   // Tunables for the cache policy.
+	CacheSize              string        `arg:"cachesize" json:"cacheSize,omitempty"`                           // Size of the cache LV.
+	VDOPool                string        `arg:"vdopool" json:"vdoPool,omitempty"`                               // The name of the VDO pool LV.
+	VDOSettings            []string      `arg:"vdosettings" json:"vdoSettings,omitempty"`                       // VDO settings in `key=value` format.
+	Compression            *YesNo        `arg:"compression" json:"compression,omitempty"`                       // Whether to enable compression.
+	Deduplication          *YesNo        `arg:"deduplication" json:"deduplication,omitempty"`                   // Whether to enable deduplication.
+	Merge                  bool          `arg:"merge" json:"merge,omitempty"`                                   // An alias for MergeMirrors, MergeSnapshot, or MergeThin depending on LV type.
+	MergeMirrors           bool          `arg:"mergemirrors" json:"mergeMirrors,omitempty"`                     // Merge LV images that were split from a raid1 LV.
+	MergeSnapshot          bool          `arg:"-mergesnapshot" json:"mergeSnapshot,omitempty"`                  // Merge COW snapshot LV into its origin.
+	MergeThin              bool          `arg:"mergethin" json:"mergeThin,omitempty"`                           // Merge thin LV into its origin LV.
+	SplitCache             bool          `arg:"splitcache" json:"splitCache,omitempty"`                         // Separates a cache pool from a cache LV, and keeps the unused cache pool LV.
+	SplitMirrors           *int          `arg:"splitmirrors" json:"splitMirrors,omitempty"`                     // Splits the specified number of images from a raid1 or mirror LV and uses them to create a new LV.
+	SplitSnapshot          bool          `arg:"splitsnapshot" json:"splitSnapshot,omitempty"`                   // Separates a COW snapshot from its origin LV.
+	Uncache                bool          `arg:"uncache" json:"uncache,omitempty"`                               // Separates a cache pool from a cache LV, and deletes the unused cache pool LV.
+	TrackChanges           bool          `arg:"trackchanges" json:"trackChanges,omitempty"`                     // Tracks changes to a raid1 LV while the split images remain detached.
+	Repair                 bool          `arg:"repair" json:"repair,omitempty"`                                 // Replace failed PVs in a raid or mirror LV, or run a repair utility on a thin pool.
+	Replace                string        `arg:"replace" json:"replace,omitempty"`                               // Replace a specific PV in a raid LV with another PV.
+}
+
+// CloneLVOptions provides options for cloning LVs.
+type CloneLVOptions struct {
+	CommonOptions
+	Origin      string `json:"origin,omitempty"`      // Name of the LV to clone, in the form vg/lv.
+	Name        string `json:"name,omitempty"`        // Name of the clone.
+	Size        string `json:"size,omitempty"`        // Space reserved for changes to a clone of a thick LV.
+	Independent bool   `json:"independent,omitempty"` // Activate a thin clone independently of its origin, rather than skipping it on activation.
 }
 
 // ExtendLVOptions provides options for adding space to an LV (lvextend).
 type ExtendLVOptions struct {
 	CommonOptions
-	Name             string   `arg:"0"`                // Name of the LV to extend.
-	PVNames          []string `arg:"1"`                // Specific PVs to extend onto.
-	AutoBackup       *YesNo   `arg:"autobackup"`       // Auto backup metadata after changes.
-	Force            bool     `arg:"force"`            // Override checks and protections.
-	Alloc            string   `arg:"alloc"`            // Allocation policy for Physical Extents.
-	UsePolicies      bool     `arg:"usepolicies"`      // Use the policy configured in lvm.conf or a profile.
-	Type             string   `arg:"type"`             // Type of LV to extend to.
-	Size             string   `arg:"size"`             // The new size of the LV.
-	Extents          string   `arg:"extents"`          // The new size of the LV in logical extents.
-	Stripes          *int     `arg:"stripes"`          // Number of stripes in a striped LV.
-	StripeSize       string   `arg:"stripesize"`       // Amount of data that is written to one device before moving to the next.
-	PoolMetadataSize string   `arg:"poolmetadatasize"` // The new size of the pool metadata LV.
-	NoSync           bool     `arg:"nosync"`           // Skips initial sync for mirror, raid*; useful for empty volumes.
-	NoUdevSync       bool     `arg:"noudevsync"`       // Ignore udev notifications.
-	ResizeFS         bool     `arg:"resizefs"`         // Resize underlying filesystem together with the LV.
-	NoFsck           bool     `arg:"nofsck"`           // Skip performing fsck before resizing the filesystem.
+	Name             string   `arg:"0" json:"name,omitempty"`                            // Name of the LV to extend.
+	PVNames          []string `arg:"1" json:"pvNames,omitempty"`                         // Specific PVs to extend onto.
+	AutoBackup       *YesNo   `arg:"autobackup" json:"autoBackup,omitempty"`             // Auto backup metadata after changes.
+	Force            bool     `arg:"force" json:"force,omitempty"`                       // Override checks and protections.
+	Alloc            string   `arg:"alloc" json:"alloc,omitempty"`                       // Allocation policy for Physical Extents.
+	UsePolicies      bool     `arg:"usepolicies" json:"usePolicies,omitempty"`           // Use the policy configured in lvm.conf or a profile.
+	Type             LVType   `arg:"type" json:"type,omitempty"`                         // Type of LV to extend to.
+	Size             string   `arg:"size" json:"size,omitempty"`                         // The new size of the LV.
+	Extents          string   `arg:"extents" json:"extents,omitempty"`                   // The new size of the LV in logical extents.
+	Stripes          *int     `arg:"stripes" json:"stripes,omitempty"`                   // Number of stripes in a striped LV.
+	StripeSize       string   `arg:"stripesize" json:"stripeSize,omitempty"`             // Amount of data that is written to one device before moving to the next.
+	PoolMetadataSize string   `arg:"poolmetadatasize" json:"poolMetadataSize,omitempty"` // The new size of the pool metadata LV.
+	NoSync           bool     `arg:"nosync" json:"noSync,omitempty"`                     // Skips initial sync for mirror, raid*; useful for empty volumes.
+	NoUdevSync       bool     `arg:"noudevsync" json:"noUdevSync,omitempty"`             // Ignore udev notifications.
+	ResizeFS         bool     `arg:"resizefs" json:"resizeFS,omitempty"`                 // Resize underlying filesystem together with the LV.
+	NoFsck           bool     `arg:"nofsck" json:"noFsck,omitempty"`                     // Skip performing fsck before resizing the filesystem.
 }
 
 // ReduceLVOptions provides options for reducing the size of an LV (lvreduce).
 type ReduceLVOptions struct {
 	CommonOptions
-	Name       string `arg:"0"`          // Name of the LV to reduce.
-	AutoBackup *YesNo `arg:"autobackup"` // Auto backup metadata after changes.
-	Force      bool   `arg:"force"`      // Override checks and protections.
-	NoUdevSync bool   `arg:"noudevsync"` // Ignore udev notifications.
-	Size       string `arg:"size"`       // The new size of the LV.
-	Extents    string `arg:"extents"`    // The new size of the LV in logical extents.
-	ResizeFS   bool   `arg:"resizefs"`   // Resize underlying filesystem together with the LV.
-	NoFsck     bool   `arg:"nofsck"`     // Skip performing fsck before resizing the filesystem.
+	Name       string `arg:"0" json:"name,omitempty"`                // Name of the LV to reduce.
+	AutoBackup *YesNo `arg:"autobackup" json:"autoBackup,omitempty"` // Auto backup metadata after changes.
+	Force      bool   `arg:"force" json:"force,omitempty"`           // Override checks and protections.
+	NoUdevSync bool   `arg:"noudevsync" json:"noUdevSync,omitempty"` // Ignore udev notifications.
+	Size       string `arg:"size" json:"size,omitempty"`             // The new size of the LV.
+	Extents    string `arg:"extents" json:"extents,omitempty"`       // The new size of the LV in logical extents.
+	ResizeFS   bool   `arg:"resizefs" json:"resizeFS,omitempty"`     // Resize underlying filesystem together with the LV.
+	NoFsck     bool   `arg:"nofsck" json:"noFsck,omitempty"`         // Skip performing fsck before resizing the filesystem.
+	Validate   bool   `json:"validate,omitempty"`                    // Check that Size would shrink the LV before running lvreduce.
 }
 
 // RenameLVOptions provides options for renaming LVs (lvrename).
 type RenameLVOptions struct {
 	CommonOptions
-	From       string `arg:"0"`          // Name of the LV to rename.
-	To         string `arg:"1"`          // New name for the LV.
-	AutoBackup *YesNo `arg:"autobackup"` // Auto backup metadata after changes.
-	NoUdevSync bool   `arg:"noudevsync"` // Ignore udev notifications.
+	From       string `arg:"0" json:"from,omitempty"`                // Name of the LV to rename, in the form vg/lv.
+	To         string `arg:"1" json:"to,omitempty"`                  // New name for the LV, either a bare name or vg/lv in the same VG.
+	AutoBackup *YesNo `arg:"autobackup" json:"autoBackup,omitempty"` // Auto backup metadata after changes.
+	NoUdevSync bool   `arg:"noudevsync" json:"noUdevSync,omitempty"` // Ignore udev notifications.
+}
+
+// PVSegment represents a contiguous range of physical extents on a PV.
+type PVSegment struct {
+	PVName string    `json:"pv_name"`     // Name of the PV the segment is on.
+	Start  IntString `json:"pvseg_start"` // Physical extent number of the start of the segment.
+	Length IntString `json:"pvseg_size"`  // Number of extents in the segment.
+	LVName string    `json:"lv_name"`     // Name of the LV the extents are allocated to, if any.
+	VGName string    `json:"vg_name"`     // Name of the VG the PV belongs to.
+}
+
+// Free returns true if the extents in the segment aren't allocated to an LV.
+func (s PVSegment) Free() bool {
+	return s.LVName == ""
+}
+
+// Report is the combined report of PVs, VGs, LVs and their segments (fullreport).
+type Report struct {
+	VGs        []VolumeGroup    `json:"vg"`    // Volume groups.
+	PVs        []PhysicalVolume `json:"pv"`    // Physical volumes.
+	LVs        []LogicalVolume  `json:"lv"`    // Logical volumes, with only the LV fields set.
+	PVSegments []PVSegment      `json:"pvseg"` // Physical volume segments.
+	Segments   []LogicalVolume  `json:"seg"`   // Logical volume segments, with only the segment fields and LV and VG names set.
+}
+
+// FullReportOptions provides options for reporting on PVs, VGs and LVs together (fullreport).
+type FullReportOptions struct {
+	CommonOptions
+	VGNames []string `arg:"0" json:"vgNames,omitempty"`       // Specific VGs to report on.
+	Select  string   `arg:"select" json:"select,omitempty"`   // Filters objects based on criteria, see Select.
+	Foreign bool     `arg:"foreign" json:"foreign,omitempty"` // Report on foreign VGs.
+	Shared  bool     `arg:"shared" json:"shared,omitempty"`   // Report on shared VGs without active lvmlockd.
+}
+
+// GetConfigOptions provides options for querying the LVM2 configuration (lvmconfig).
+type GetConfigOptions struct {
+	CommonOptions
+	Keys            []string `arg:"0" json:"keys,omitempty"`                          // Configuration sections or settings to display, eg. `devices/filter`.
+	Type            string   `arg:"type" json:"type,omitempty"`                       // Type of configuration to display, defaults to `full`.
+	MergedConfig    bool     `arg:"mergedconfig" json:"mergedConfig,omitempty"`       // Merge the command profile into the configuration.
+	IgnoreLocal     bool     `arg:"ignorelocal" json:"ignoreLocal,omitempty"`         // Ignore the local section of the configuration.
+	MetadataProfile string   `arg:"metadataprofile" json:"metadataProfile,omitempty"` // Merge the metadata profile into the configuration.
 }
 
 // CommonOptions holds configurations for LVM2 commands.
 type CommonOptions struct {
-	Config      string   `arg:"config"`      // Overrides lvm.conf settings.
-	NoLocking   bool     `arg:"nolocking"`   // Disables locking.
-	LockOpt     string   `arg:"lockopt"`     // Options for lvmlockd.
-	Profile     string   `arg:"profile"`     // Command profile.
-	DevicesFile string   `arg:"devicesfile"` // LVM device file (from /etc/lvm/devices/).
-	Devices     []string `arg:"devices"`     // Overrides lvm.conf devices.
-	NoHints     bool     `arg:"nohints"`     // Disables PV location hint.
-	Journal     string   `arg:"journal"`     // Logs in systemd journal.
+	Config      string   `arg:"config" json:"config,omitempty"`           // Overrides lvm.conf settings.
+	NoLocking   bool     `arg:"nolocking" json:"noLocking,omitempty"`     // Disables locking.
+	LockOpt     string   `arg:"lockopt" json:"lockOpt,omitempty"`         // Options for lvmlockd.
+	Profile     string   `arg:"profile" json:"profile,omitempty"`         // Command profile.
+	DevicesFile string   `arg:"devicesfile" json:"devicesFile,omitempty"` // LVM device file (from /etc/lvm/devices/).
+	Devices     []string `arg:"devices" json:"devices,omitempty"`         // Overrides lvm.conf devices.
+	NoHints     bool     `arg:"nohints" json:"noHints,omitempty"`         // Disables PV location hint.
+	Journal     string   `arg:"journal" json:"journal,omitempty"`         // Logs in systemd journal.
+	// Extra arguments for flags that aren't otherwise supported. They are
+	// passed verbatim after all other flags and positional arguments.
+	RawArgs []string `json:"rawArgs,omitempty"`
+}
+
+func (o CommonOptions) rawArgs() []string {
+	return o.RawArgs
+}
+
+// withByteUnits returns the options with sizes reported in bytes, so that they
+// aren't rounded. The caller's raw arguments are kept.
+func (o CommonOptions) withByteUnits() CommonOptions {
+	o.RawArgs = append(append([]string{}, o.RawArgs...), "--units=b")
+	return o
 }
@@ -0,0 +1,140 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLVTypeJSON(t *testing.T) {
+	var lv lvm2.LogicalVolume
+	err := json.Unmarshal([]byte(`{"lv_name":"lv0","segtype":"raid1"}`), &lv)
+	require.NoError(t, err)
+	require.Equal(t, lvm2.LVTypeRAID1, lv.Type)
+
+	data, err := json.Marshal(lvm2.LVTypeThinPool)
+	require.NoError(t, err)
+	require.JSONEq(t, `"thin-pool"`, string(data))
+
+	var lvType lvm2.LVType
+	err = json.Unmarshal(data, &lvType)
+	require.NoError(t, err)
+	require.Equal(t, lvm2.LVTypeThinPool, lvType)
+
+	require.Equal(t, "thin-pool", lvm2.LVTypeThinPool.MarshalArg())
+}
+
+func TestBoolStringJSON(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "1", want: true},
+		{value: "0", want: false},
+		{value: "", want: false},
+		{value: "-1", want: false},
+		{value: "allocatable", want: true},
+		{value: "unknown", want: false},
+	}
+
+	for _, tt := range tests {
+		var pv lvm2.PhysicalVolume
+		err := json.Unmarshal([]byte(`{"pv_allocatable":"`+tt.value+`"}`), &pv)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, bool(pv.Allocatable), "value %q", tt.value)
+	}
+
+	var vg lvm2.VolumeGroup
+	err := json.Unmarshal([]byte(`{"vg_exported":"1","vg_partial":"0"}`), &vg)
+	require.NoError(t, err)
+	require.True(t, bool(vg.Exported))
+	require.False(t, bool(vg.Partial))
+
+	var lv lvm2.LogicalVolume
+	err = json.Unmarshal([]byte(`{"lv_active_locally":"1","lv_device_open":""}`), &lv)
+	require.NoError(t, err)
+	require.True(t, bool(lv.ActiveLocally))
+	require.False(t, bool(lv.DeviceOpen))
+}
+
+func TestActivationJSON(t *testing.T) {
+	for _, activate := range []lvm2.Activation{lvm2.ActivateYes, lvm2.ActivateNo, lvm2.ActivateExclusive, ""} {
+		createOpts := lvm2.CreateLVOptions{
+			Name:     "lv0",
+			VGName:   "vg0",
+			Size:     "16M",
+			Activate: activate,
+		}
+
+		data, err := json.Marshal(createOpts)
+		require.NoError(t, err)
+
+		var decodedCreate lvm2.CreateLVOptions
+		require.NoError(t, json.Unmarshal(data, &decodedCreate))
+		require.Equal(t, lvm2.CommandLine(createOpts), lvm2.CommandLine(decodedCreate))
+
+		updateVGOpts := lvm2.UpdateVGOptions{Name: "vg0", Activate: activate}
+
+		data, err = json.Marshal(updateVGOpts)
+		require.NoError(t, err)
+
+		var decodedUpdateVG lvm2.UpdateVGOptions
+		require.NoError(t, json.Unmarshal(data, &decodedUpdateVG))
+		require.Equal(t, lvm2.CommandLine(updateVGOpts), lvm2.CommandLine(decodedUpdateVG))
+
+		updateLVOpts := lvm2.UpdateLVOptions{Name: "vg0/lv0", Activate: activate}
+
+		data, err = json.Marshal(updateLVOpts)
+		require.NoError(t, err)
+
+		var decodedUpdateLV lvm2.UpdateLVOptions
+		require.NoError(t, json.Unmarshal(data, &decodedUpdateLV))
+		require.Equal(t, lvm2.CommandLine(updateLVOpts), lvm2.CommandLine(decodedUpdateLV))
+	}
+
+	scanOpts := lvm2.ScanPVOptions{Cache: true, Activate: lvm2.ActivateAuto}
+
+	data, err := json.Marshal(scanOpts)
+	require.NoError(t, err)
+
+	var decodedScan lvm2.ScanPVOptions
+	require.NoError(t, json.Unmarshal(data, &decodedScan))
+	require.Equal(t, []string{"pvscan", "--cache", "--activate=ay"}, lvm2.CommandLine(decodedScan))
+
+	t.Log("Other fields are decoded alongside the activation value")
+
+	var opts lvm2.CreateLVOptions
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"lv0","vgName":"vg0","activate":"ey","rawArgs":["--verbose"]}`), &opts))
+	require.Equal(t, "lv0", opts.Name)
+	require.Equal(t, lvm2.ActivateExclusive, opts.Activate)
+	require.Equal(t, []string{"--verbose"}, opts.RawArgs)
+
+	t.Log("Activation states encoded as booleans")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"activate":true}`), &opts))
+	require.Equal(t, lvm2.ActivateYes, opts.Activate)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"activate":false}`), &opts))
+	require.Equal(t, lvm2.ActivateNo, opts.Activate)
+
+	require.Error(t, json.Unmarshal([]byte(`{"activate":1}`), &opts))
+}
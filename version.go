@@ -0,0 +1,154 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionNumberRegexp = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)`)
+
+// VersionNumber is a comparable major.minor.patch version number.
+type VersionNumber struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is less than, equal to,
+// or greater than other.
+func (v VersionNumber) Compare(other VersionNumber) int {
+	switch {
+	case v.Major != other.Major:
+		return compareInts(v.Major, other.Major)
+	case v.Minor != other.Minor:
+		return compareInts(v.Minor, other.Minor)
+	default:
+		return compareInts(v.Patch, other.Patch)
+	}
+}
+
+// AtLeast returns true if v is greater than or equal to other.
+func (v VersionNumber) AtLeast(other VersionNumber) bool {
+	return v.Compare(other) >= 0
+}
+
+func (v VersionNumber) String() string {
+	return fmt.Sprintf("%d.%02d.%02d", v.Major, v.Minor, v.Patch)
+}
+
+// Version holds the versions of the LVM2 tools and the components they use.
+type Version struct {
+	LVM     VersionNumber // Version of the LVM2 tools.
+	Library VersionNumber // Version of the device-mapper library.
+	Driver  VersionNumber // Version of the device-mapper kernel driver.
+}
+
+// SupportsVDO returns true if the LVM2 tools are new enough to manage VDO
+// volumes. The feature may still be unavailable if it was not compiled in.
+func (v Version) SupportsVDO() bool {
+	return v.LVM.AtLeast(VersionNumber{Major: 2, Minor: 3, Patch: 0})
+}
+
+// SupportsWriteCache returns true if the LVM2 tools are new enough to manage
+// dm-writecache volumes.
+func (v Version) SupportsWriteCache() bool {
+	return v.LVM.AtLeast(VersionNumber{Major: 2, Minor: 3, Patch: 2})
+}
+
+// SupportsIntegrity returns true if the LVM2 tools are new enough to manage
+// RAID volumes with dm-integrity.
+func (v Version) SupportsIntegrity() bool {
+	return v.LVM.AtLeast(VersionNumber{Major: 2, Minor: 3, Patch: 9})
+}
+
+// ParseVersion parses the output of `lvm version`.
+func ParseVersion(out []byte) (Version, error) {
+	var v Version
+	var foundLVM bool
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		var dst *VersionNumber
+		switch strings.TrimSpace(key) {
+		case "LVM version":
+			dst = &v.LVM
+			foundLVM = true
+		case "Library version":
+			dst = &v.Library
+		case "Driver version":
+			dst = &v.Driver
+		default:
+			continue
+		}
+
+		n, err := parseVersionNumber(strings.TrimSpace(value))
+		if err != nil {
+			return Version{}, fmt.Errorf("failed to parse %s: %w", strings.TrimSpace(key), err)
+		}
+		*dst = n
+	}
+	if err := scanner.Err(); err != nil {
+		return Version{}, err
+	}
+
+	if !foundLVM {
+		return Version{}, fmt.Errorf("missing LVM version")
+	}
+
+	return v, nil
+}
+
+func parseVersionNumber(s string) (VersionNumber, error) {
+	m := versionNumberRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return VersionNumber{}, fmt.Errorf("invalid version number: %q", s)
+	}
+
+	var parts [3]int
+	for i := range parts {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return VersionNumber{}, err
+		}
+		parts[i] = n
+	}
+
+	return VersionNumber{Major: parts[0], Minor: parts[1], Patch: parts[2]}, nil
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,52 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"testing"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	out := `  LVM version:     2.03.16(2) (2022-05-18)
+  Library version: 1.02.185 (2022-05-18)
+  Driver version:  4.47.0
+  Configuration:   ./configure --build=x86_64-linux-gnu --prefix=/usr
+`
+
+	v, err := lvm2.ParseVersion([]byte(out))
+	require.NoError(t, err)
+
+	require.Equal(t, lvm2.VersionNumber{Major: 2, Minor: 3, Patch: 16}, v.LVM)
+	require.Equal(t, lvm2.VersionNumber{Major: 1, Minor: 2, Patch: 185}, v.Library)
+	require.Equal(t, lvm2.VersionNumber{Major: 4, Minor: 47, Patch: 0}, v.Driver)
+	require.Equal(t, "2.03.16", v.LVM.String())
+
+	require.True(t, v.SupportsVDO())
+	require.True(t, v.SupportsWriteCache())
+	require.True(t, v.SupportsIntegrity())
+
+	old := lvm2.Version{LVM: lvm2.VersionNumber{Major: 2, Minor: 2, Patch: 187}}
+	require.False(t, old.SupportsVDO())
+	require.Equal(t, -1, old.LVM.Compare(v.LVM))
+
+	_, err = lvm2.ParseVersion([]byte("garbage"))
+	require.Error(t, err)
+}
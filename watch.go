@@ -0,0 +1,188 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// DefaultWatchInterval is how often Watch polls for changes, unless overridden
+// with WithWatchInterval.
+const DefaultWatchInterval = 5 * time.Second
+
+// EventType is the kind of change reported by Watch.
+type EventType string
+
+const (
+	EventCreated     EventType = "created"
+	EventRemoved     EventType = "removed"
+	EventActivated   EventType = "activated"
+	EventDeactivated EventType = "deactivated"
+	EventResized     EventType = "resized"
+)
+
+// Event is a change to a volume group or logical volume.
+type Event struct {
+	Type EventType // Kind of change.
+	VG   string    // Name of the VG.
+	LV   string    // Name of the LV, or empty if the event is for the VG itself.
+}
+
+// Watch for changes to volume groups and logical volumes. Changes are detected
+// by polling a full report and comparing it with the previous one, so changes
+// that are undone between polls aren't reported. The returned channel is
+// closed when the context is done. Failed polls are skipped, and the next poll
+// is compared with the last successful one.
+func (c *Client) Watch(ctx context.Context) (<-chan Event, error) {
+	prev, err := c.watchSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := c.watchInterval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			next, err := c.watchSnapshot(ctx)
+			if err != nil {
+				continue
+			}
+
+			for _, event := range prev.diff(next) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			prev = next
+		}
+	}()
+
+	return events, nil
+}
+
+// snapshot is the state of the volume groups and logical volumes at a point
+// in time, as compared by Watch.
+type snapshot struct {
+	vgs map[string]struct{}
+	// lvs is keyed by the full name of each LV, ie. vg/lv.
+	lvs map[string]LogicalVolume
+}
+
+func (c *Client) watchSnapshot(ctx context.Context) (*snapshot, error) {
+	report, err := c.FullReport(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &snapshot{
+		vgs: make(map[string]struct{}),
+		lvs: make(map[string]LogicalVolume),
+	}
+
+	for _, vg := range report.VGs {
+		s.vgs[vg.Name] = struct{}{}
+	}
+
+	for _, lv := range report.LVs {
+		s.lvs[lv.VGName+"/"+lv.Name] = lv
+	}
+
+	return s, nil
+}
+
+// diff returns the events needed to get from s to next. New VGs are reported
+// before their LVs, and removed VGs after their LVs.
+func (s *snapshot) diff(next *snapshot) []Event {
+	var events []Event
+
+	for _, name := range sortedKeys(next.vgs) {
+		if _, ok := s.vgs[name]; !ok {
+			events = append(events, Event{Type: EventCreated, VG: name})
+		}
+	}
+
+	for _, name := range sortedKeys(next.lvs) {
+		lv := next.lvs[name]
+
+		prev, ok := s.lvs[name]
+		if !ok {
+			events = append(events, Event{Type: EventCreated, VG: lv.VGName, LV: lv.Name})
+			continue
+		}
+
+		if lv.Size != prev.Size {
+			events = append(events, Event{Type: EventResized, VG: lv.VGName, LV: lv.Name})
+		}
+
+		if active := lv.IsActive(); active != prev.IsActive() {
+			eventType := EventDeactivated
+			if active {
+				eventType = EventActivated
+			}
+
+			events = append(events, Event{Type: eventType, VG: lv.VGName, LV: lv.Name})
+		}
+	}
+
+	for _, name := range sortedKeys(s.lvs) {
+		if _, ok := next.lvs[name]; !ok {
+			lv := s.lvs[name]
+			events = append(events, Event{Type: EventRemoved, VG: lv.VGName, LV: lv.Name})
+		}
+	}
+
+	for _, name := range sortedKeys(s.vgs) {
+		if _, ok := next.vgs[name]; !ok {
+			events = append(events, Event{Type: EventRemoved, VG: name})
+		}
+	}
+
+	return events
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,93 @@
+/* SPDX-License-Identifier: Apache-2.0
+ *
+ * Copyright 2023 Damian Peckett <damian@pecke.tt>.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lvm2_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dpeckett/lvm2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+
+	// Writes the report returned by the fake, with the given LVs in vg0.
+	setReport := func(lvs string) {
+		report := fmt.Sprintf(`{"report":[{"vg":[{"vg_name":"vg0"}],"lv":[%s]}]}`, lvs)
+		require.NoError(t, os.WriteFile(reportPath, []byte(report), 0o644))
+	}
+
+	setReport("")
+
+	c := lvm2.NewClient(
+		lvm2.WithLVM(fakeLVM(t, fmt.Sprintf("cat %s", reportPath))),
+		lvm2.WithWatchInterval(10*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	events, err := c.Watch(ctx)
+	require.NoError(t, err)
+
+	next := func() lvm2.Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for event")
+			return lvm2.Event{}
+		}
+	}
+
+	t.Log("Creating a logical volume")
+
+	setReport(`{"lv_name":"lv0","vg_name":"vg0","lv_size":"64.00m","lv_active":""}`)
+	require.Equal(t, lvm2.Event{Type: lvm2.EventCreated, VG: "vg0", LV: "lv0"}, next())
+
+	t.Log("Activating the logical volume")
+
+	setReport(`{"lv_name":"lv0","vg_name":"vg0","lv_size":"64.00m","lv_active":"active"}`)
+	require.Equal(t, lvm2.Event{Type: lvm2.EventActivated, VG: "vg0", LV: "lv0"}, next())
+
+	t.Log("Resizing the logical volume")
+
+	setReport(`{"lv_name":"lv0","vg_name":"vg0","lv_size":"128.00m","lv_active":"active"}`)
+	require.Equal(t, lvm2.Event{Type: lvm2.EventResized, VG: "vg0", LV: "lv0"}, next())
+
+	t.Log("Removing the logical volume and volume group")
+
+	require.NoError(t, os.WriteFile(reportPath, []byte(`{"report":[]}`), 0o644))
+	require.Equal(t, lvm2.Event{Type: lvm2.EventRemoved, VG: "vg0", LV: "lv0"}, next())
+	require.Equal(t, lvm2.Event{Type: lvm2.EventRemoved, VG: "vg0"}, next())
+
+	t.Log("The channel is closed when the context is done")
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, ok := <-events
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}